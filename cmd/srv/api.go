@@ -139,6 +139,8 @@ func (s *srv) loadAPIRouter() *router.Router {
 		router.POST(onlyTokenAuthRouter, "/updateQuestPosition", s.questDomain.UpdatePosition)
 		router.POST(onlyTokenAuthRouter, "/deleteQuest", s.questDomain.Delete)
 		router.POST(onlyTokenAuthRouter, "/parseTemplate", s.questDomain.ParseTemplate)
+		router.GET(onlyTokenAuthRouter, "/exportQuests", s.questDomain.Export)
+		router.POST(onlyTokenAuthRouter, "/importQuests", s.questDomain.Import)
 
 		// Category API
 		router.POST(onlyTokenAuthRouter, "/createCategory", s.categoryDomain.Create)
@@ -148,6 +150,8 @@ func (s *srv) loadAPIRouter() *router.Router {
 		// Claimed Quest API
 		router.POST(onlyTokenAuthRouter, "/claim", s.claimedQuestDomain.Claim)
 		router.POST(onlyTokenAuthRouter, "/claimReferral", s.claimedQuestDomain.ClaimReferral)
+		router.POST(onlyTokenAuthRouter, "/appealClaimedQuest", s.claimedQuestDomain.Appeal)
+		router.POST(onlyTokenAuthRouter, "/updateClaimedQuest", s.claimedQuestDomain.Update)
 
 		// Image API
 		router.POST(onlyTokenAuthRouter, "/uploadImage", s.fileDomain.UploadImage)
@@ -226,9 +230,15 @@ func (s *srv) loadAPIRouter() *router.Router {
 	{
 		router.GET(tokenAndKeyAuthRouter, "/getClaimedQuest", s.claimedQuestDomain.Get)
 		router.GET(tokenAndKeyAuthRouter, "/getClaimedQuests", s.claimedQuestDomain.GetList)
+		router.GETRaw(tokenAndKeyAuthRouter, "/exportClaimedQuests", s.claimedQuestDomain.Export)
 		router.POST(tokenAndKeyAuthRouter, "/review", s.claimedQuestDomain.Review)
 		router.POST(tokenAndKeyAuthRouter, "/reviewAll", s.claimedQuestDomain.ReviewAll)
+		router.POST(tokenAndKeyAuthRouter, "/reviewByFile", s.claimedQuestDomain.ReviewByFile)
+		router.POST(tokenAndKeyAuthRouter, "/leaseClaimedQuests", s.claimedQuestDomain.Lease)
+		router.POST(tokenAndKeyAuthRouter, "/releaseClaimedQuests", s.claimedQuestDomain.Release)
+		router.GET(tokenAndKeyAuthRouter, "/getReviewerStatistic", s.claimedQuestDomain.GetReviewerStatistic)
 		router.POST(tokenAndKeyAuthRouter, "/givePoint", s.claimedQuestDomain.GivePoint)
+		router.GET(tokenAndKeyAuthRouter, "/getSurveyResult", s.claimedQuestDomain.GetSurveyResult)
 	}
 
 	// Public API
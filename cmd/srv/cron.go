@@ -9,10 +9,17 @@ import (
 )
 
 func (s *srv) startCron(*cli.Context) error {
+	rpcSearchClient, err := rpc.DialContext(s.ctx, xcontext.Configs(s.ctx).SearchServer.Endpoint)
+	if err != nil {
+		return err
+	}
+
 	s.ctx = xcontext.WithDB(s.ctx, s.newDatabase())
 	s.migrateDB()
 	s.loadRedisClient()
-	s.loadRepos(nil)
+	s.loadRepos(client.NewSearchCaller(rpcSearchClient))
+	s.loadBadgeManager()
+	s.loadQuestFactory(nil)
 
 	rpcNotificationEngineClient, err := rpc.DialContext(s.ctx,
 		xcontext.Configs(s.ctx).Notification.EngineRPCServer.Endpoint)
@@ -27,6 +34,8 @@ func (s *srv) startCron(*cli.Context) error {
 		cron.NewCleanupUserStatusCronJob(s.followerRepo, s.userRepo, s.redisClient,
 			client.NewNotificationEngineCaller(rpcNotificationEngineClient)),
 		cron.NewSetDailyCommunityStatCronJob(s.communityRepo, s.userRepo, s.followerRepo, s.redisClient),
+		cron.NewQuestScheduleCronJob(s.questRepo),
+		cron.NewQuestRaffleCronJob(s.questRepo, s.claimedQuestRepo, s.questRaffleRepo, s.questFactory),
 	)
 
 	return nil
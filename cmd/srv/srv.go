@@ -63,6 +63,8 @@ type srv struct {
 	chatChannelBucketRepo repository.ChatChannelBucketRepository
 	lotteryRepo           repository.LotteryRepository
 	nftRepo               repository.NftRepository
+	surveyAnswerRepo      repository.SurveyAnswerRepository
+	questRaffleRepo       repository.QuestRaffleRepository
 
 	userDomain         domain.UserDomain
 	authDomain         domain.AuthDomain
@@ -240,6 +242,9 @@ func (s *srv) loadConfig() config.Configs {
 			QuizMaxQuestions:                 parseInt(getEnv("QUIZ_MAX_QUESTIONS", "10")),
 			QuizMaxOptions:                   parseInt(getEnv("QUIZ_MAX_OPTIONS", "10")),
 			InviteReclaimDelay:               parseDuration(getEnv("INVITE_RECLAIM_DELAY", "1m")),
+			BlockchainReclaimDelay:           parseDuration(getEnv("BLOCKCHAIN_RECLAIM_DELAY", "1m")),
+			ChatReclaimDelay:                 parseDuration(getEnv("CHAT_RECLAIM_DELAY", "1m")),
+			ReviewLeaseTimeout:               parseDuration(getEnv("REVIEW_LEASE_TIMEOUT", "15m")),
 			InviteCommunityRequiredFollowers: parseInt(getEnv("INVITE_COMMUNITY_REQUIRED_FOLLOWERS", "10000")),
 			InviteCommunityRewardChain: getEnv("INVITE_COMMUNITY_REWARD_CHAIN",
 				"avaxc-testnet"),
@@ -372,6 +377,8 @@ func (s *srv) loadRepos(searchCaller client.SearchCaller) {
 	s.chatChannelBucketRepo = repository.NewChatBucketRepository(s.scyllaDBSession)
 	s.lotteryRepo = repository.NewLotteryRepository()
 	s.nftRepo = repository.NewNftRepository()
+	s.surveyAnswerRepo = repository.NewSurveyAnswerRepository()
+	s.questRaffleRepo = repository.NewQuestRaffleRepository()
 }
 
 func (s *srv) loadBadgeManager() {
@@ -384,6 +391,15 @@ func (s *srv) loadBadgeManager() {
 	)
 }
 
+func (s *srv) loadQuestFactory(blockchainCaller client.BlockchainCaller) {
+	s.questFactory = questclaim.NewFactory(s.claimedQuestRepo, s.questRepo, s.communityRepo,
+		s.followerRepo, s.oauth2Repo, s.userRepo, s.payRewardRepo, s.blockchainRepo,
+		s.lotteryRepo, s.nftRepo, s.chatChannelRepo, s.chatMessageRepo, s.chatReactionRepo,
+		s.badgeRepo, s.badgeDetailRepo, s.roleRepo, s.followerRoleRepo, s.badgeManager,
+		s.twitterEndpoint, s.discordEndpoint, s.telegramEndpoint, blockchainCaller,
+	)
+}
+
 func (s *srv) loadDomains(
 	blockchainCaller client.BlockchainCaller,
 	notificationEngineCaller client.NotificationEngineCaller,
@@ -396,10 +412,7 @@ func (s *srv) loadDomains(
 	oauth2Services = append(oauth2Services, authenticator.NewOAuth2Service(s.ctx, cfg.Auth.Discord))
 
 	s.roleVerifier = common.NewCommunityRoleVerifier(s.followerRoleRepo, s.roleRepo, s.userRepo)
-	s.questFactory = questclaim.NewFactory(s.claimedQuestRepo, s.questRepo, s.communityRepo,
-		s.followerRepo, s.oauth2Repo, s.userRepo, s.payRewardRepo, s.blockchainRepo,
-		s.lotteryRepo, s.nftRepo, s.twitterEndpoint, s.discordEndpoint, s.telegramEndpoint,
-	)
+	s.loadQuestFactory(blockchainCaller)
 
 	s.authDomain = domain.NewAuthDomain(s.ctx, s.userRepo, s.refreshTokenRepo, s.oauth2Repo,
 		oauth2Services, s.twitterEndpoint, s.storage)
@@ -414,8 +427,8 @@ func (s *srv) loadDomains(
 	s.categoryDomain = domain.NewCategoryDomain(s.categoryRepo, s.questRepo, s.communityRepo, s.roleVerifier)
 	s.claimedQuestDomain = domain.NewClaimedQuestDomain(s.claimedQuestRepo, s.questRepo,
 		s.followerRepo, s.followerRoleRepo, s.userRepo, s.communityRepo, s.categoryRepo,
-		s.badgeManager, s.leaderboard, s.roleVerifier, notificationEngineCaller, s.questFactory,
-		s.redisClient)
+		s.surveyAnswerRepo, s.oauth2Repo, s.badgeManager, s.leaderboard, s.roleVerifier, notificationEngineCaller,
+		s.questFactory, s.redisClient)
 	s.fileDomain = domain.NewFileDomain(s.storage, s.fileRepo)
 	s.apiKeyDomain = domain.NewAPIKeyDomain(s.apiKeyRepo, s.communityRepo, s.roleVerifier)
 	s.statisticDomain = domain.NewStatisticDomain(s.claimedQuestRepo, s.followerRepo, s.userRepo,
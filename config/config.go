@@ -147,6 +147,9 @@ type QuestConfigs struct {
 	QuizMaxOptions                   int
 	InviteReclaimDelay               time.Duration
 	InviteCommunityRequiredFollowers int
+	BlockchainReclaimDelay           time.Duration
+	ChatReclaimDelay                 time.Duration
+	ReviewLeaseTimeout               time.Duration
 
 	InviteCommunityRewardChain        string
 	InviteCommunityRewardTokenAddress string
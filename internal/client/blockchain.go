@@ -15,6 +15,7 @@ type BlockchainCaller interface {
 	ERC20TokenInfo(ctx context.Context, chain, address string) (types.TokenInfo, error)
 	ERC20BalanceOf(ctx context.Context, chain, tokenAddress, accountAddress string) (*big.Int, error)
 	ERC1155BalanceOf(ctx context.Context, chain, address string, tokenID int64) (*big.Int, error)
+	TransactionReceipt(ctx context.Context, chain, txHash string) (types.TransactionReceipt, error)
 	DeployNFT(ctx context.Context, chain string) (string, error)
 	Close()
 }
@@ -61,6 +62,18 @@ func (c *blockchainCaller) ERC1155BalanceOf(
 	return result, nil
 }
 
+func (c *blockchainCaller) TransactionReceipt(
+	ctx context.Context, chain, txHash string,
+) (types.TransactionReceipt, error) {
+	var result types.TransactionReceipt
+	err := c.client.CallContext(ctx, &result, c.fname(ctx, "transactionReceipt"), chain, txHash)
+	if err != nil {
+		return types.TransactionReceipt{}, err
+	}
+
+	return result, nil
+}
+
 func (c *blockchainCaller) MintNFT(
 	ctx context.Context, communityID, chain string, nftID int64, amount int, ipfs string,
 ) error {
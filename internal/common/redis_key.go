@@ -29,3 +29,14 @@ func FromRedisValueFollower(value string) (string, string) {
 	parts := strings.Split(value, "***")
 	return parts[0], parts[1]
 }
+
+// RedisKeyReviewLease stores the reviewer who is leasing the claimed quest.
+func RedisKeyReviewLease(claimedQuestID string) string {
+	return fmt.Sprintf("reviewlease:%s", claimedQuestID)
+}
+
+// RedisKeyReviewLeases stores claimed quests which may be leased in the
+// community. The lease of a member may be expired.
+func RedisKeyReviewLeases(communityID string) string {
+	return fmt.Sprintf("reviewleases:%s", communityID)
+}
@@ -11,10 +11,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts"
 	ethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 	"github.com/questx-lab/backend/internal/common"
 	"github.com/questx-lab/backend/internal/entity"
@@ -24,6 +21,7 @@ import (
 	"github.com/questx-lab/backend/pkg/authenticator"
 	"github.com/questx-lab/backend/pkg/crypto"
 	"github.com/questx-lab/backend/pkg/errorx"
+	"github.com/questx-lab/backend/pkg/ethutil"
 	"github.com/questx-lab/backend/pkg/storage"
 	"github.com/questx-lab/backend/pkg/xcontext"
 	"gorm.io/gorm"
@@ -454,24 +452,12 @@ func (d *authDomain) generateRefreshToken(ctx context.Context, userID string) (s
 }
 
 func (d *authDomain) verifyWalletAnswer(ctx context.Context, hexSignature, sessionNonce, sessionAddress string) error {
-	hash := accounts.TextHash([]byte(sessionNonce))
-	signature, err := hexutil.Decode(hexSignature)
-	if err != nil {
-		xcontext.Logger(ctx).Errorf("Cannot decode signature: %v", err)
-		return errorx.Unknown
-	}
-
-	if signature[ethcrypto.RecoveryIDOffset] == 27 || signature[ethcrypto.RecoveryIDOffset] == 28 {
-		signature[ethcrypto.RecoveryIDOffset] -= 27 // Transform yellow paper V from 27/28 to 0/1
-	}
-
-	recovered, err := ethcrypto.SigToPub(hash, signature)
+	recoveredAddr, err := ethutil.RecoverTextSigner([]byte(sessionNonce), hexSignature)
 	if err != nil {
 		xcontext.Logger(ctx).Errorf("Cannot recover signature to address: %v", err)
 		return errorx.Unknown
 	}
 
-	recoveredAddr := ethcrypto.PubkeyToAddress(*recovered)
 	if !bytes.Equal(recoveredAddr.Bytes(), ethcommon.HexToAddress(sessionAddress).Bytes()) {
 		return errorx.New(errorx.BadRequest, "Mismatched address")
 	}
@@ -89,7 +89,7 @@ func (d *badgeDomain) UpdateBadge(
 		return nil, errorx.New(errorx.BadRequest, "Invalid icon url")
 	}
 
-	err = d.badgeRepo.Create(ctx, &entity.Badge{
+	err = d.badgeRepo.UpsertSystemBadge(ctx, &entity.Badge{
 		Base:        entity.Base{ID: uuid.NewString()},
 		Name:        req.Name,
 		Level:       req.Level,
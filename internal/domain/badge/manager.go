@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/questx-lab/backend/internal/common"
 	"github.com/questx-lab/backend/internal/entity"
 	"github.com/questx-lab/backend/internal/repository"
@@ -44,6 +45,75 @@ func (m *Manager) GetAllBadgeNames() []string {
 	return common.MapKeys(m.badgeScanners)
 }
 
+// CheckCommunityBadge returns an error if the community cannot define a badge
+// with the given name. Names of scanned badges are reserved.
+func (m *Manager) CheckCommunityBadge(name string) error {
+	if _, ok := m.badgeScanners[name]; ok {
+		return errorx.New(errorx.Unavailable, "Badge name %s is reserved", name)
+	}
+
+	return nil
+}
+
+// GiveCommunityBadge gives a badge defined by community to user. The badge will
+// be created if it doesn't exist. The user only receives the badge if they
+// have not owned a higher level of it yet.
+func (m *Manager) GiveCommunityBadge(
+	ctx context.Context, userID, communityID string, communityBadge entity.Badge,
+) error {
+	if err := m.CheckCommunityBadge(communityBadge.Name); err != nil {
+		return err
+	}
+
+	// Create the badge if it doesn't exist, then read it with a lock. A plain
+	// read in the current transaction cannot see the badge created by another
+	// request at the same time, but a locking read always returns the latest
+	// committed row.
+	communityBadge.ID = uuid.NewString()
+	communityBadge.CommunityID = sql.NullString{Valid: true, String: communityID}
+	if err := m.badgeRepo.CreateIfNotExists(ctx, &communityBadge); err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot create community badge: %v", err)
+		return errorx.Unknown
+	}
+
+	badge, err := m.badgeRepo.GetForUpdate(ctx, communityID, communityBadge.Name, communityBadge.Level)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get badge: %v", err)
+		return errorx.Unknown
+	}
+
+	latestBadgeDetail, err := m.badgeDetailRepo.GetLatest(ctx, userID, communityID, badge.Name)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			xcontext.Logger(ctx).Errorf("Cannot get the latest badge detail: %v", err)
+			return errorx.Unknown
+		}
+	} else {
+		latestBadge, err := m.badgeRepo.GetByID(ctx, latestBadgeDetail.BadgeID)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot get the latest badge: %v", err)
+			return errorx.Unknown
+		}
+
+		if latestBadge.Level >= badge.Level {
+			return nil
+		}
+	}
+
+	err = m.badgeDetailRepo.Create(ctx, &entity.BadgeDetail{
+		UserID:      userID,
+		CommunityID: sql.NullString{Valid: true, String: communityID},
+		BadgeID:     badge.ID,
+		WasNotified: false,
+	})
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot create new badge to user: %v", err)
+		return errorx.Unknown
+	}
+
+	return nil
+}
+
 func (m *Manager) WithBadges(badgeNames ...string) *contextManager {
 	return &contextManager{
 		manager:    m,
@@ -44,7 +44,7 @@ func Test_badgeDomain_FollowCommunity_and_GetMyBadges(t *testing.T) {
 
 	claimedQuestDomain := NewClaimedQuestDomain(
 		claimedQuestRepo, questRepo, followerRepo, followerRoleRepo, userRepo,
-		communityRepo, categoryRepo, badge.NewManager(
+		communityRepo, categoryRepo, repository.NewSurveyAnswerRepository(), repository.NewOAuth2Repository(), badge.NewManager(
 			badgeRepo,
 			badgeDetailRepo,
 			&testutil.MockBadge{
@@ -121,3 +121,41 @@ func Test_badgeDomain_FollowCommunity_and_GetMyBadges(t *testing.T) {
 	require.Equal(t, testutil.BadgeSharpScout1.ID, badges.BadgeDetails[0].Badge.ID)
 	require.True(t, badges.BadgeDetails[0].WasNotified)
 }
+
+func Test_badgeDomain_UpdateBadge(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+
+	badgeRepo := repository.NewBadgeRepository()
+	badgeDomain := NewBadgeDomain(
+		badgeRepo,
+		repository.NewBadgeDetailRepository(),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		badge.NewManager(
+			badgeRepo, repository.NewBadgeDetailRepository(),
+			&testutil.MockBadge{NameValue: badge.QuestWarriorBadgeName},
+		),
+	)
+
+	// Updating the same system badge twice must not create another one.
+	for _, value := range []int{100, 200} {
+		_, err := badgeDomain.UpdateBadge(ctx, &model.UpdateBadgeRequest{
+			Name:    badge.QuestWarriorBadgeName,
+			Level:   9,
+			Value:   value,
+			IconURL: "https://example.com/icon.png",
+		})
+		require.NoError(t, err)
+	}
+
+	var count int64
+	require.NoError(t, xcontext.DB(ctx).Model(&entity.Badge{}).
+		Where("name=? AND level=?", badge.QuestWarriorBadgeName, 9).
+		Count(&count).Error)
+	require.Equal(t, int64(1), count)
+
+	b, err := badgeRepo.Get(ctx, "", badge.QuestWarriorBadgeName, 9)
+	require.NoError(t, err)
+	require.Equal(t, 200, b.Value)
+	require.False(t, b.CommunityID.Valid)
+}
@@ -48,6 +48,7 @@ type EthClient interface {
 	BlockNumber(ctx context.Context) (uint64, error)
 	BlockByNumber(ctx context.Context, number *big.Int) (*ethtypes.Block, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (*ethtypes.Transaction, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 	SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error
@@ -413,6 +414,19 @@ func (c *defaultEthClient) TransactionReceipt(ctx context.Context, txHash common
 	return receipt.(*ethtypes.Receipt), nil
 }
 
+func (c *defaultEthClient) TransactionByHash(ctx context.Context, txHash common.Hash) (*ethtypes.Transaction, error) {
+	tx, err := c.execute(ctx, func(client *ethclient.Client, rpc string) (any, error) {
+		tx, _, err := client.TransactionByHash(ctx, txHash)
+		return tx, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.(*ethtypes.Transaction), nil
+}
+
 func (c *defaultEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	gas, err := c.execute(ctx, func(client *ethclient.Client, rpc string) (any, error) {
 		return client.SuggestGasPrice(ctx)
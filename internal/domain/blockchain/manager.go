@@ -8,6 +8,7 @@ import (
 	"time"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"github.com/questx-lab/backend/internal/domain/blockchain/eth"
 	"github.com/questx-lab/backend/internal/domain/blockchain/types"
@@ -119,6 +120,53 @@ func (m *BlockchainManager) ERC1155BalanceOf(
 	return client.ERC1155BalanceOf(m.rootCtx, address, tokenID)
 }
 
+func (m *BlockchainManager) TransactionReceipt(
+	_ context.Context, chain, txHash string,
+) (types.TransactionReceipt, error) {
+	client, ok := m.ethClients[chain]
+	if !ok {
+		return types.TransactionReceipt{}, fmt.Errorf("unsupported chain %s", chain)
+	}
+
+	hash := ethcommon.HexToHash(txHash)
+	receipt, err := client.TransactionReceipt(m.rootCtx, hash)
+	if err != nil {
+		return types.TransactionReceipt{}, err
+	}
+
+	tx, err := client.TransactionByHash(m.rootCtx, hash)
+	if err != nil {
+		return types.TransactionReceipt{}, err
+	}
+
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return types.TransactionReceipt{}, err
+	}
+
+	result := types.TransactionReceipt{
+		TxHash:  receipt.TxHash.Hex(),
+		Success: receipt.Status == ethtypes.ReceiptStatusSuccessful,
+		From:    from.Hex(),
+		Input:   tx.Data(),
+	}
+
+	if tx.To() != nil {
+		result.To = tx.To().Hex()
+	}
+
+	for _, log := range receipt.Logs {
+		receiptLog := types.ReceiptLog{Address: log.Address.Hex()}
+		for _, topic := range log.Topics {
+			receiptLog.Topics = append(receiptLog.Topics, topic.Hex())
+		}
+
+		result.Logs = append(result.Logs, receiptLog)
+	}
+
+	return result, nil
+}
+
 func (m *BlockchainManager) MintNFT(
 	_ context.Context, communityID, chain string, nftID int64, amount int, ipfs string,
 ) error {
@@ -24,3 +24,18 @@ type Txs struct {
 	BaseFee     *big.Int
 	PriorityFee *big.Int
 }
+
+// TransactionReceipt is a summary of a mined transaction and its receipt.
+type TransactionReceipt struct {
+	TxHash  string
+	Success bool
+	From    string
+	To      string
+	Input   []byte
+	Logs    []ReceiptLog
+}
+
+type ReceiptLog struct {
+	Address string
+	Topics  []string
+}
@@ -3,7 +3,14 @@ package domain
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,7 +38,15 @@ type ClaimedQuestDomain interface {
 	GetList(context.Context, *model.GetListClaimedQuestRequest) (*model.GetListClaimedQuestResponse, error)
 	Review(context.Context, *model.ReviewRequest) (*model.ReviewResponse, error)
 	ReviewAll(context.Context, *model.ReviewAllRequest) (*model.ReviewAllResponse, error)
+	ReviewByFile(context.Context, *model.ReviewByFileRequest) (*model.ReviewByFileResponse, error)
 	GivePoint(context.Context, *model.GivePointRequest) (*model.GivePointResponse, error)
+	GetSurveyResult(context.Context, *model.GetSurveyResultRequest) (*model.GetSurveyResultResponse, error)
+	Appeal(context.Context, *model.AppealClaimedQuestRequest) (*model.AppealClaimedQuestResponse, error)
+	Update(context.Context, *model.UpdateClaimedQuestRequest) (*model.UpdateClaimedQuestResponse, error)
+	Lease(context.Context, *model.LeaseClaimedQuestsRequest) (*model.LeaseClaimedQuestsResponse, error)
+	Release(context.Context, *model.ReleaseClaimedQuestsRequest) (*model.ReleaseClaimedQuestsResponse, error)
+	GetReviewerStatistic(context.Context, *model.GetReviewerStatisticRequest) (*model.GetReviewerStatisticResponse, error)
+	Export(context.Context, *model.ExportClaimedQuestsRequest) error
 }
 
 type claimedQuestDomain struct {
@@ -41,6 +56,8 @@ type claimedQuestDomain struct {
 	followerRoleRepo         repository.FollowerRoleRepository
 	communityRepo            repository.CommunityRepository
 	categoryRepo             repository.CategoryRepository
+	surveyAnswerRepo         repository.SurveyAnswerRepository
+	oauth2Repo               repository.OAuth2Repository
 	roleVerifier             *common.CommunityRoleVerifier
 	userRepo                 repository.UserRepository
 	questFactory             questclaim.Factory
@@ -58,6 +75,8 @@ func NewClaimedQuestDomain(
 	userRepo repository.UserRepository,
 	communityRepo repository.CommunityRepository,
 	categoryRepo repository.CategoryRepository,
+	surveyAnswerRepo repository.SurveyAnswerRepository,
+	oauth2Repo repository.OAuth2Repository,
 	badgeManager *badge.Manager,
 	leaderboard statistic.Leaderboard,
 	roleVerifier *common.CommunityRoleVerifier,
@@ -74,6 +93,8 @@ func NewClaimedQuestDomain(
 		communityRepo:            communityRepo,
 		roleVerifier:             roleVerifier,
 		categoryRepo:             categoryRepo,
+		surveyAnswerRepo:         surveyAnswerRepo,
+		oauth2Repo:               oauth2Repo,
 		questFactory:             questFactory,
 		badgeManager:             badgeManager,
 		leaderboard:              leaderboard,
@@ -157,7 +178,9 @@ func (d *claimedQuestDomain) Claim(
 		return nil, err
 	}
 
-	actionForClaim, err := processor.GetActionForClaim(ctx, req.SubmissionData)
+	req.SubmissionData = questclaim.NormalizeSubmissionData(processor, req.SubmissionData)
+	actionForClaim, err := processor.GetActionForClaim(
+		questclaim.WithClaimWalletAddress(ctx, req.WalletAddress), req.SubmissionData)
 	if err != nil {
 		return nil, err
 	}
@@ -227,14 +250,73 @@ func (d *claimedQuestDomain) Claim(
 		}
 	}
 
+	// IsClaimable has checked the limit per user, but concurrent claims of the
+	// same user may pass it together, so check it again with a lock.
+	if quest.MaxClaimsPerUser > 0 && status != entity.AutoRejected {
+		if err := d.lockFollower(ctx, requestUserID, quest.CommunityID.String); err != nil {
+			return nil, err
+		}
+
+		claims, err := d.claimedQuestRepo.CountUserClaims(ctx, quest.ID, requestUserID,
+			[]entity.ClaimedQuestStatus{entity.Pending, entity.Appealed, entity.Accepted, entity.AutoAccepted})
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot count claims of user: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if claims >= int64(quest.MaxClaimsPerUser) {
+			return nil, errorx.New(errorx.Unavailable, "You have reached the maximum number of claims for this quest")
+		}
+	}
+
 	err = d.claimedQuestRepo.Create(ctx, claimedQuest)
 	if err != nil {
 		xcontext.Logger(ctx).Errorf("Cannot claim quest: %v", err)
 		return nil, errorx.Unknown
 	}
 
+	err = d.claimedQuestRepo.CreateHistories(ctx, []entity.ClaimedQuestHistory{{
+		Base:           entity.Base{ID: uuid.NewString()},
+		ClaimedQuestID: claimedQuest.ID,
+		ActorID:        requestUserID,
+		Status:         status,
+		SubmissionData: req.SubmissionData,
+	}})
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot create claimed quest history: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	// Store answers of survey in structured form, so they can be aggregated
+	// later.
+	if quest.Type == entity.QuestSurvey && status != entity.AutoRejected {
+		answers, err := d.questFactory.ParseSurveyAnswers(ctx, *quest, req.SubmissionData)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range answers {
+			answers[i].ClaimedQuestID = claimedQuest.ID
+		}
+
+		if err := d.surveyAnswerRepo.CreateMany(ctx, answers); err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot create survey answers: %v", err)
+			return nil, errorx.Unknown
+		}
+	}
+
 	// Give reward to user if the claimed quest is accepted.
 	if status == entity.AutoAccepted {
+		ok, err := d.questRepo.IncreaseClaimedCount(ctx, quest.ID)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot increase claimed count: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if !ok {
+			return nil, errorx.New(errorx.Unavailable, "This quest has no remaining slots")
+		}
+
 		if err := d.giveReward(ctx, *quest, *claimedQuest); err != nil {
 			return nil, err
 		}
@@ -351,11 +433,22 @@ func (d *claimedQuestDomain) Get(
 		}
 	}
 
+	histories, err := d.claimedQuestRepo.GetHistories(ctx, claimedQuest.ID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get claimed quest histories: %v", err)
+		return nil, errorx.Unknown
+	}
+
 	resp := model.GetClaimedQuestResponse(model.ConvertClaimedQuest(
 		claimedQuest,
 		model.ConvertQuest(quest, model.ConvertCommunity(community, 0), model.ConvertCategory(category)),
 		model.ConvertShortUser(user, ""),
 	))
+
+	for i := range histories {
+		resp.History = append(resp.History, model.ConvertClaimedQuestHistory(&histories[i]))
+	}
+
 	return &resp, nil
 }
 
@@ -412,10 +505,300 @@ func (d *claimedQuestDomain) GetList(
 		return nil, errorx.New(errorx.BadRequest, "Exceed the maximum of limit (%d)", apiCfg.MaxLimit)
 	}
 
+	filter, err := parseClaimedQuestFilter(ctx, req.Status, req.Recurrence, req.QuestID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.CommunityID = communityID
+	filter.Offset = req.Offset
+	filter.Limit = req.Limit
+	filter.ReverseTime = req.ReverseTime
+
+	// Claimed quests leased by another reviewer are hidden from reviewers.
+	if communityID != "" && req.UserID != xcontext.RequestUserID(ctx) {
+		leases, err := d.getReviewLeases(ctx, communityID)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, reviewerID := range leases {
+			if reviewerID != xcontext.RequestUserID(ctx) {
+				filter.ExcludeIDs = append(filter.ExcludeIDs, id)
+			}
+		}
+	}
+
+	claimedQuests, err := d.claimedQuestRepo.GetList(ctx, filter)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get list claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	clientClaimedQuests, err := d.convertClaimedQuests(ctx, communityID, claimedQuests)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GetListClaimedQuestResponse{ClaimedQuests: clientClaimedQuests}, nil
+}
+
+// exportClaimedQuestBatchSize is the number of claimed quests loaded from
+// database each time when exporting.
+const exportClaimedQuestBatchSize = 500
+
+var exportClaimedQuestCSVHeader = []string{
+	"id", "quest_id", "quest_title", "user_id", "username", "oauth2", "wallet_address",
+	"submission_data", "status", "reviewer_id", "reviewer_name", "comment",
+	"reviewed_at", "created_at", "updated_at",
+}
+
+func (d *claimedQuestDomain) Export(ctx context.Context, req *model.ExportClaimedQuestsRequest) error {
+	if req.CommunityHandle == "" {
+		return errorx.New(errorx.BadRequest, "Not allow empty community handle")
+	}
+
+	community, err := d.communityRepo.GetByHandle(ctx, req.CommunityHandle)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorx.New(errorx.NotFound, "Not found community")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get community: %v", err)
+		return errorx.Unknown
+	}
+
+	if err := d.roleVerifier.Verify(ctx, community.ID); err != nil {
+		xcontext.Logger(ctx).Debugf("Permission denied: %v", err)
+		return errorx.New(errorx.PermissionDenied, "Permission denied")
+	}
+
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+
+	if req.Format != "csv" && req.Format != "ndjson" {
+		return errorx.New(errorx.BadRequest, "Format must be csv or ndjson")
+	}
+
+	filter, err := parseClaimedQuestFilter(ctx, req.Status, req.Recurrence, req.QuestID, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	filter.CommunityID = community.ID
+	filter.Limit = exportClaimedQuestBatchSize
+
+	// Load the first batch before writing anything, so any error can still be
+	// returned as a normal response.
+	claimedQuests, rows, err := d.loadExportBatch(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	writer := xcontext.HTTPWriter(ctx)
+	writer.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%s_claimed_quests.%s", community.Handle, req.Format))
+
+	var csvWriter *csv.Writer
+	var jsonEncoder *json.Encoder
+	if req.Format == "csv" {
+		writer.Header().Set("Content-Type", "text/csv")
+		csvWriter = csv.NewWriter(writer)
+	} else {
+		writer.Header().Set("Content-Type", "application/x-ndjson")
+		jsonEncoder = json.NewEncoder(writer)
+	}
+
+	// The response has started from here, an error response cannot be written
+	// anymore. If any error occurs, log it and end the response.
+	if csvWriter != nil {
+		if err := csvWriter.Write(exportClaimedQuestCSVHeader); err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot write csv header: %v", err)
+			return nil
+		}
+	}
+
+	for {
+		for _, row := range rows {
+			if csvWriter != nil {
+				err = csvWriter.Write(exportedClaimedQuestCSVRecord(row))
+			} else {
+				err = jsonEncoder.Encode(row)
+			}
+
+			if err != nil {
+				xcontext.Logger(ctx).Errorf("Cannot write exported claimed quest: %v", err)
+				return nil
+			}
+		}
+
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				xcontext.Logger(ctx).Errorf("Cannot flush csv: %v", err)
+				return nil
+			}
+		}
+
+		if flusher, ok := writer.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		if len(claimedQuests) < exportClaimedQuestBatchSize {
+			break
+		}
+
+		last := claimedQuests[len(claimedQuests)-1]
+		filter.AfterCreatedAt = last.CreatedAt
+		filter.AfterID = last.ID
+
+		claimedQuests, rows, err = d.loadExportBatch(ctx, filter)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot load the next batch of exported claimed quests: %v", err)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// loadExportBatch loads a batch of claimed quests and their exported rows.
+func (d *claimedQuestDomain) loadExportBatch(
+	ctx context.Context, filter *repository.ClaimedQuestFilter,
+) ([]entity.ClaimedQuest, []model.ExportedClaimedQuest, error) {
+	claimedQuests, err := d.claimedQuestRepo.GetList(ctx, filter)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get claimed quests: %v", err)
+		return nil, nil, errorx.Unknown
+	}
+
+	rows, err := d.exportClaimedQuests(ctx, claimedQuests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return claimedQuests, rows, nil
+}
+
+// exportClaimedQuests loads the quest, user, linked OAuth2 accounts, and
+// reviewer of claimed quests for exporting.
+func (d *claimedQuestDomain) exportClaimedQuests(
+	ctx context.Context, claimedQuests []entity.ClaimedQuest,
+) ([]model.ExportedClaimedQuest, error) {
+	if len(claimedQuests) == 0 {
+		return nil, nil
+	}
+
+	questSet := map[string]any{}
+	claimerSet := map[string]any{}
+	userSet := map[string]any{}
+	for _, cq := range claimedQuests {
+		questSet[cq.QuestID] = nil
+		claimerSet[cq.UserID] = nil
+		userSet[cq.UserID] = nil
+		if cq.ReviewerID != "" {
+			userSet[cq.ReviewerID] = nil
+		}
+	}
+
+	quests, err := d.questRepo.GetByIDsIncludeSoftDeleted(ctx, common.MapKeys(questSet))
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get quests: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	questMap := map[string]entity.Quest{}
+	for _, q := range quests {
+		questMap[q.ID] = q
+	}
+
+	users, err := d.userRepo.GetByIDs(ctx, common.MapKeys(userSet))
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get users: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	userMap := map[string]entity.User{}
+	for _, u := range users {
+		userMap[u.ID] = u
+	}
+
+	oauth2Records, err := d.oauth2Repo.GetAllByUserIDs(ctx, common.MapKeys(claimerSet)...)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get oauth2 records: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	oauth2Map := map[string]map[string]string{}
+	for _, record := range oauth2Records {
+		if _, ok := oauth2Map[record.UserID]; !ok {
+			oauth2Map[record.UserID] = map[string]string{}
+		}
+
+		oauth2Map[record.UserID][record.Service] = record.ServiceUsername
+	}
+
+	rows := []model.ExportedClaimedQuest{}
+	for _, cq := range claimedQuests {
+		user := userMap[cq.UserID]
+		walletAddress := cq.WalletAddress
+		if walletAddress == "" {
+			walletAddress = user.WalletAddress.String
+		}
+
+		reviewedAt := ""
+		if cq.ReviewedAt.Valid {
+			reviewedAt = cq.ReviewedAt.Time.Format(model.DefaultTimeLayout)
+		}
+
+		rows = append(rows, model.ExportedClaimedQuest{
+			ID:             cq.ID,
+			QuestID:        cq.QuestID,
+			QuestTitle:     questMap[cq.QuestID].Title,
+			UserID:         cq.UserID,
+			Username:       user.Name,
+			OAuth2:         oauth2Map[cq.UserID],
+			WalletAddress:  walletAddress,
+			SubmissionData: cq.SubmissionData,
+			Status:         string(cq.Status),
+			ReviewerID:     cq.ReviewerID,
+			ReviewerName:   userMap[cq.ReviewerID].Name,
+			Comment:        cq.Comment,
+			ReviewedAt:     reviewedAt,
+			CreatedAt:      cq.CreatedAt.Format(model.DefaultTimeLayout),
+			UpdatedAt:      cq.UpdatedAt.Format(model.DefaultTimeLayout),
+		})
+	}
+
+	return rows, nil
+}
+
+// exportedClaimedQuestCSVRecord returns the csv record of exported claimed
+// quest, the order of fields must match exportClaimedQuestCSVHeader. OAuth2
+// usernames are joined in form of service:username.
+func exportedClaimedQuestCSVRecord(row model.ExportedClaimedQuest) []string {
+	oauth2 := []string{}
+	for service, username := range row.OAuth2 {
+		oauth2 = append(oauth2, fmt.Sprintf("%s:%s", service, username))
+	}
+	sort.Strings(oauth2)
+
+	return []string{
+		row.ID, row.QuestID, row.QuestTitle, row.UserID, row.Username, strings.Join(oauth2, ";"),
+		row.WalletAddress, row.SubmissionData, row.Status, row.ReviewerID, row.ReviewerName,
+		row.Comment, row.ReviewedAt, row.CreatedAt, row.UpdatedAt,
+	}
+}
+
+// parseClaimedQuestFilter parses comma-separated filters of claimed quests.
+func parseClaimedQuestFilter(
+	ctx context.Context, status, recurrence, questID, userID string,
+) (*repository.ClaimedQuestFilter, error) {
 	var statusFilter []entity.ClaimedQuestStatus
-	if req.Status != "" {
-		status := strings.Split(req.Status, ",")
-		for _, s := range status {
+	if status != "" {
+		statuses := strings.Split(status, ",")
+		for _, s := range statuses {
 			statusEnum, err := enum.ToEnum[entity.ClaimedQuestStatus](s)
 			if err != nil {
 				xcontext.Logger(ctx).Debugf("Invalid claimed quest status: %v", err)
@@ -427,8 +810,8 @@ func (d *claimedQuestDomain) GetList(
 	}
 
 	var recurrenceFilter []entity.RecurrenceType
-	if req.Recurrence != "" {
-		recurrences := strings.Split(req.Recurrence, ",")
+	if recurrence != "" {
+		recurrences := strings.Split(recurrence, ",")
 		for _, recurrence := range recurrences {
 			recurrenceEnum, err := enum.ToEnum[entity.RecurrenceType](recurrence)
 			if err != nil {
@@ -441,33 +824,28 @@ func (d *claimedQuestDomain) GetList(
 	}
 
 	var userIDFilter []string
-	if len(req.UserID) > 0 {
-		userIDFilter = strings.Split(req.UserID, ",")
+	if len(userID) > 0 {
+		userIDFilter = strings.Split(userID, ",")
 	}
 
 	var questIDFilter []string
-	if len(req.QuestID) > 0 {
-		questIDFilter = strings.Split(req.QuestID, ",")
+	if len(questID) > 0 {
+		questIDFilter = strings.Split(questID, ",")
 	}
 
-	claimedQuests, err := d.claimedQuestRepo.GetList(
-		ctx,
-		&repository.ClaimedQuestFilter{
-			CommunityID: communityID,
-			Status:      statusFilter,
-			Recurrences: recurrenceFilter,
-			QuestIDs:    questIDFilter,
-			UserIDs:     userIDFilter,
-			Offset:      req.Offset,
-			Limit:       req.Limit,
-			ReverseTime: req.ReverseTime,
-		},
-	)
-	if err != nil {
-		xcontext.Logger(ctx).Errorf("Cannot get list claimed quest: %v", err)
-		return nil, errorx.Unknown
-	}
+	return &repository.ClaimedQuestFilter{
+		Status:      statusFilter,
+		Recurrences: recurrenceFilter,
+		QuestIDs:    questIDFilter,
+		UserIDs:     userIDFilter,
+	}, nil
+}
 
+// convertClaimedQuests converts claimed quests to client form, including their
+// quest, community, category, and user.
+func (d *claimedQuestDomain) convertClaimedQuests(
+	ctx context.Context, communityID string, claimedQuests []entity.ClaimedQuest,
+) ([]model.ClaimedQuest, error) {
 	questMap := map[string]*entity.Quest{}
 	userMap := map[string]*entity.User{}
 	for _, cq := range claimedQuests {
@@ -569,7 +947,7 @@ func (d *claimedQuestDomain) GetList(
 		)
 	}
 
-	return &model.GetListClaimedQuestResponse{ClaimedQuests: clientClaimedQuests}, nil
+	return clientClaimedQuests, nil
 }
 
 func (d *claimedQuestDomain) Review(
@@ -602,7 +980,24 @@ func (d *claimedQuestDomain) Review(
 		return nil, errorx.Unknown
 	}
 
-	if err := d.review(ctx, claimedQuests, req.Action, req.Comment); err != nil {
+	override := reviewOverride{}
+	if req.Points != nil {
+		if *req.Points > math.MaxInt64 {
+			return nil, errorx.New(errorx.BadRequest, "Points is too large")
+		}
+
+		override.points = sql.NullInt64{Valid: true, Int64: int64(*req.Points)}
+	}
+
+	if req.RewardScale != nil {
+		if *req.RewardScale < 0 || *req.RewardScale > 1 {
+			return nil, errorx.New(errorx.BadRequest, "Reward scale must be between 0 and 1")
+		}
+
+		override.rewardScale = sql.NullFloat64{Valid: true, Float64: *req.RewardScale}
+	}
+
+	if err := d.review(ctx, claimedQuests, req.Action, req.Comment, override); err != nil {
 		return nil, err
 	}
 
@@ -680,6 +1075,18 @@ func (d *claimedQuestDomain) ReviewAll(
 		excludeMap[id] = nil
 	}
 
+	// Claimed quests leased by another reviewer are also excluded.
+	leases, err := d.getReviewLeases(ctx, community.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, reviewerID := range leases {
+		if reviewerID != xcontext.RequestUserID(ctx) {
+			excludeMap[id] = nil
+		}
+	}
+
 	finalClaimedQuests := []entity.ClaimedQuest{}
 	for _, cq := range claimedQuests {
 		if _, ok := excludeMap[cq.ID]; !ok {
@@ -687,118 +1094,1077 @@ func (d *claimedQuestDomain) ReviewAll(
 		}
 	}
 
-	if err := d.review(ctx, finalClaimedQuests, req.Action, req.Comment); err != nil {
+	if err := d.review(ctx, finalClaimedQuests, req.Action, req.Comment, reviewOverride{}); err != nil {
 		return nil, err
 	}
 
 	return &model.ReviewAllResponse{Quantity: len(finalClaimedQuests)}, nil
 }
 
-func (d *claimedQuestDomain) review(
-	ctx context.Context,
-	claimedQuests []entity.ClaimedQuest,
-	action string,
-	comment string,
-) error {
-	if len(claimedQuests) == 0 {
-		return errorx.New(errorx.Unavailable, "No claimed quest will be reviewed")
+// A decision file is applied in chunks of rows, each row has its own savepoint
+// so that a failed row doesn't abort the other rows in the same chunk.
+const (
+	reviewByFileMaxRows   = 5000
+	reviewByFileChunkSize = 100
+	reviewByFileSavePoint = "review_by_file_row"
+)
+
+type reviewDecision struct {
+	result       *model.ReviewByFileResult
+	claimedQuest entity.ClaimedQuest
+	comment      string
+	override     reviewOverride
+}
+
+func (d *claimedQuestDomain) ReviewByFile(
+	ctx context.Context, req *model.ReviewByFileRequest,
+) (*model.ReviewByFileResponse, error) {
+	httpReq := xcontext.HTTPRequest(ctx)
+	cfg := xcontext.Configs(ctx).File
+
+	httpReq.Body = http.MaxBytesReader(xcontext.HTTPWriter(ctx), httpReq.Body, cfg.MaxSize)
+	if err := httpReq.ParseMultipartForm(cfg.MaxMemory); err != nil {
+		xcontext.Logger(ctx).Debugf("Cannot parse multipart form: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "File too large")
 	}
 
-	reviewAction, err := enum.ToEnum[entity.ClaimedQuestStatus](action)
+	community, err := d.communityRepo.GetByHandle(ctx, httpReq.PostFormValue("community_handle"))
 	if err != nil {
-		xcontext.Logger(ctx).Debugf("Invalid review action: %v", err)
-		return errorx.New(errorx.BadRequest, "Invalid action")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found community")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get community: %v", err)
+		return nil, errorx.Unknown
 	}
 
-	questSet := map[string]any{}
-	claimedQuestSet := map[string]any{}
-	for _, cq := range claimedQuests {
-		switch reviewAction {
-		case entity.Pending: // Unapprove
-			if cq.Status != entity.Accepted && cq.Status != entity.Rejected {
-				return errorx.New(errorx.BadRequest, "Claimed quest %s must be accepted or rejected", cq.ID)
-			}
-		case entity.Accepted, entity.Rejected:
-			if cq.Status != entity.Pending {
-				return errorx.New(errorx.BadRequest, "Claimed quest %s must be pending", cq.ID)
-			}
-		default:
-			return errorx.New(errorx.BadRequest, "Review action must be accepted, rejected, or pending")
-		}
+	if err := d.roleVerifier.Verify(ctx, community.ID); err != nil {
+		xcontext.Logger(ctx).Debugf("Permission denied: %v", err)
+		return nil, errorx.New(errorx.PermissionDenied, "Permission denied")
+	}
 
-		claimedQuestSet[cq.ID] = nil
-		questSet[cq.QuestID] = nil
+	file, _, err := httpReq.FormFile("file")
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Cannot get form file: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Require a decision file")
 	}
+	defer file.Close()
 
-	quests, err := d.questRepo.GetByIDsIncludeSoftDeleted(ctx, common.MapKeys(questSet))
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
 	if err != nil {
-		xcontext.Logger(ctx).Errorf("Cannot get quest: %v", err)
-		return errorx.Unknown
+		xcontext.Logger(ctx).Debugf("Cannot read decision file: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid csv file")
 	}
 
-	questInverse := map[string]entity.Quest{}
-	for _, q := range quests {
-		if q.CommunityID != quests[0].CommunityID {
-			return errorx.New(errorx.BadRequest, "You can only review claimed quests of one community")
-		}
+	// The header is optional.
+	firstRow := 1
+	if len(records) > 0 && len(records[0]) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "id") {
+		records = records[1:]
+		firstRow = 2
+	}
 
-		questInverse[q.ID] = q
+	if len(records) == 0 {
+		return nil, errorx.New(errorx.BadRequest, "The decision file is empty")
 	}
 
-	ctx = xcontext.WithDBTransaction(ctx)
+	if len(records) > reviewByFileMaxRows {
+		return nil, errorx.New(errorx.BadRequest, "The decision file must not exceed %d rows", reviewByFileMaxRows)
+	}
+
+	results := make([]model.ReviewByFileResult, len(records))
+	comments := make([]string, len(records))
+	overrides := make([]reviewOverride, len(records))
+	claimedQuestRows := map[string]int{}
+	for i, record := range records {
+		results[i].Row = firstRow + i
+		if len(record) < 2 {
+			results[i].Error = "Require at least id and action"
+			continue
+		}
+
+		results[i].ID = strings.TrimSpace(record[0])
+		results[i].Action = strings.TrimSpace(record[1])
+		if len(record) > 2 {
+			comments[i] = record[2]
+		}
+
+		if results[i].ID == "" {
+			results[i].Error = "Not allow empty id"
+			continue
+		}
+
+		if _, err := enum.ToEnum[entity.ClaimedQuestStatus](results[i].Action); err != nil {
+			results[i].Error = "Invalid action"
+			continue
+		}
+
+		if len(record) > 3 && strings.TrimSpace(record[3]) != "" {
+			points, err := strconv.ParseInt(strings.TrimSpace(record[3]), 10, 64)
+			if err != nil || points < 0 {
+				results[i].Error = "Invalid points"
+				continue
+			}
+
+			overrides[i].points = sql.NullInt64{Valid: true, Int64: points}
+		}
+
+		if row, ok := claimedQuestRows[results[i].ID]; ok {
+			results[i].Error = fmt.Sprintf("Duplicated with row %d", results[row].Row)
+			continue
+		}
+
+		claimedQuestRows[results[i].ID] = i
+	}
+
+	claimedQuests, err := d.claimedQuestRepo.GetByIDs(ctx, common.MapKeys(claimedQuestRows))
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get claimed quests: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	questSet := map[string]any{}
+	for _, cq := range claimedQuests {
+		questSet[cq.QuestID] = nil
+	}
+
+	quests, err := d.questRepo.GetByIDsIncludeSoftDeleted(ctx, common.MapKeys(questSet))
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get quests: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	communityQuests := map[string]any{}
+	for _, q := range quests {
+		if q.CommunityID.String == community.ID {
+			communityQuests[q.ID] = nil
+		}
+	}
+
+	claimedQuestInverse := map[string]entity.ClaimedQuest{}
+	for _, cq := range claimedQuests {
+		if _, ok := communityQuests[cq.QuestID]; ok {
+			claimedQuestInverse[cq.ID] = cq
+		}
+	}
+
+	// Keep the order of rows in the file, so later decisions are applied
+	// after earlier ones.
+	decisions := []reviewDecision{}
+	for i := range results {
+		if results[i].Error != "" {
+			continue
+		}
+
+		claimedQuest, ok := claimedQuestInverse[results[i].ID]
+		if !ok {
+			results[i].Error = "Not found claimed quest in community"
+			continue
+		}
+
+		decisions = append(decisions, reviewDecision{
+			result:       &results[i],
+			claimedQuest: claimedQuest,
+			comment:      comments[i],
+			override:     overrides[i],
+		})
+	}
+
+	for start := 0; start < len(decisions); start += reviewByFileChunkSize {
+		end := start + reviewByFileChunkSize
+		if end > len(decisions) {
+			end = len(decisions)
+		}
+
+		d.applyReviewDecisions(ctx, decisions[start:end])
+	}
+
+	resp := &model.ReviewByFileResponse{Results: results}
+	for _, result := range results {
+		if result.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	return resp, nil
+}
+
+// applyReviewDecisions applies a chunk of decisions in one transaction. A
+// failed decision is rolled back alone and reported in its result. If the
+// savepoint cannot be created or restored, the whole chunk is rolled back.
+func (d *claimedQuestDomain) applyReviewDecisions(ctx context.Context, decisions []reviewDecision) {
+	ctx = xcontext.WithDBTransaction(ctx)
+	defer xcontext.WithRollbackDBTransaction(ctx)
+
+	abortChunk := func() {
+		for _, decision := range decisions {
+			decision.result.Success = false
+			if decision.result.Error == "" {
+				decision.result.Error = errorx.Unknown.Error()
+			}
+		}
+	}
+
+	leasedIDs := []string{}
+	for _, decision := range decisions {
+		if err := xcontext.DB(ctx).SavePoint(reviewByFileSavePoint).Error; err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot create savepoint: %v", err)
+			abortChunk()
+			return
+		}
+
+		ids, err := d.applyReview(
+			ctx,
+			[]entity.ClaimedQuest{decision.claimedQuest},
+			decision.result.Action,
+			decision.comment,
+			decision.override,
+		)
+		if err != nil {
+			if err := xcontext.DB(ctx).RollbackTo(reviewByFileSavePoint).Error; err != nil {
+				xcontext.Logger(ctx).Errorf("Cannot rollback to savepoint: %v", err)
+				abortChunk()
+				return
+			}
+
+			decision.result.Error = err.Error()
+			continue
+		}
+
+		decision.result.Success = true
+		leasedIDs = append(leasedIDs, ids...)
+	}
+
+	xcontext.WithCommitDBTransaction(ctx)
+	d.releaseReviewLeases(ctx, leasedIDs)
+}
+
+// reviewOverride is set by reviewers to grant a different amount of points
+// and to scale coin rewards of accepted claimed quests.
+type reviewOverride struct {
+	points      sql.NullInt64
+	rewardScale sql.NullFloat64
+}
+
+func (d *claimedQuestDomain) review(
+	ctx context.Context,
+	claimedQuests []entity.ClaimedQuest,
+	action string,
+	comment string,
+	override reviewOverride,
+) error {
+	ctx = xcontext.WithDBTransaction(ctx)
+	defer xcontext.WithRollbackDBTransaction(ctx)
+
+	leasedIDs, err := d.applyReview(ctx, claimedQuests, action, comment, override)
+	if err != nil {
+		return err
+	}
+
+	xcontext.WithCommitDBTransaction(ctx)
+	d.releaseReviewLeases(ctx, leasedIDs)
+
+	return nil
+}
+
+// applyReview reviews the claimed quests within the database transaction of
+// context and returns ids of the claimed quests leased before reviewing. The
+// caller is responsible for committing the transaction and releasing leases.
+func (d *claimedQuestDomain) applyReview(
+	ctx context.Context,
+	claimedQuests []entity.ClaimedQuest,
+	action string,
+	comment string,
+	override reviewOverride,
+) ([]string, error) {
+	if len(claimedQuests) == 0 {
+		return nil, errorx.New(errorx.Unavailable, "No claimed quest will be reviewed")
+	}
+
+	reviewAction, err := enum.ToEnum[entity.ClaimedQuestStatus](action)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Invalid review action: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid action")
+	}
+
+	if reviewAction != entity.Accepted && (override.points.Valid || override.rewardScale.Valid) {
+		return nil, errorx.New(errorx.BadRequest, "Only allow to override points or rewards when accepting")
+	}
+
+	questSet := map[string]any{}
+	claimedQuestSet := map[string]any{}
+	for _, cq := range claimedQuests {
+		switch reviewAction {
+		case entity.Pending: // Unapprove
+			if cq.Status != entity.Accepted && cq.Status != entity.Rejected {
+				return nil, errorx.New(errorx.BadRequest, "Claimed quest %s must be accepted or rejected", cq.ID)
+			}
+		case entity.Accepted, entity.Rejected:
+			if cq.Status != entity.Pending && cq.Status != entity.Appealed {
+				return nil, errorx.New(errorx.BadRequest, "Claimed quest %s must be pending or appealed", cq.ID)
+			}
+		default:
+			return nil, errorx.New(errorx.BadRequest, "Review action must be accepted, rejected, or pending")
+		}
+
+		claimedQuestSet[cq.ID] = nil
+		questSet[cq.QuestID] = nil
+	}
+
+	leaseOwners, err := d.getLeaseOwners(ctx, common.MapKeys(claimedQuestSet))
+	if err != nil {
+		return nil, err
+	}
+
+	for id, reviewerID := range leaseOwners {
+		if reviewerID != xcontext.RequestUserID(ctx) {
+			return nil, errorx.New(errorx.Unavailable, "Claimed quest %s is being reviewed by another reviewer", id)
+		}
+	}
+
+	quests, err := d.questRepo.GetByIDsIncludeSoftDeleted(ctx, common.MapKeys(questSet))
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	questInverse := map[string]entity.Quest{}
+	for _, q := range quests {
+		if q.CommunityID != quests[0].CommunityID {
+			return nil, errorx.New(errorx.BadRequest, "You can only review claimed quests of one community")
+		}
+
+		questInverse[q.ID] = q
+	}
+
+	if reviewAction == entity.Accepted {
+		if err := d.checkClaimLimitPerUser(ctx, claimedQuests, questInverse); err != nil {
+			return nil, err
+		}
+	}
+
+	requestUserID := xcontext.RequestUserID(ctx)
+	err = d.claimedQuestRepo.UpdateReviewByIDs(
+		ctx, common.MapKeys(claimedQuestSet),
+		&entity.ClaimedQuest{
+			Status:     reviewAction,
+			Comment:    comment,
+			ReviewerID: requestUserID,
+			ReviewedAt: sql.NullTime{Valid: true, Time: time.Now()},
+		},
+	)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Unable to update status: %v", err)
+		return nil, errorx.New(errorx.Internal, "Unable to update status for claim quest")
+	}
+
+	histories := []entity.ClaimedQuestHistory{}
+	for _, cq := range claimedQuests {
+		histories = append(histories, entity.ClaimedQuestHistory{
+			Base:           entity.Base{ID: uuid.NewString()},
+			ClaimedQuestID: cq.ID,
+			ActorID:        requestUserID,
+			Status:         reviewAction,
+			Comment:        comment,
+		})
+	}
+
+	if err := d.claimedQuestRepo.CreateHistories(ctx, histories); err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot create claimed quest histories: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	switch reviewAction {
+	case entity.Accepted:
+		// Always update the override to clear the one of a previous review.
+		err := d.claimedQuestRepo.UpdateOverrideByIDs(
+			ctx, common.MapKeys(claimedQuestSet), override.points, override.rewardScale)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot update review override: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		for _, claimedQuest := range claimedQuests {
+			quest, ok := questInverse[claimedQuest.QuestID]
+			if !ok {
+				xcontext.Logger(ctx).Errorf(
+					"Not found quest %s of claimed quest %s", claimedQuest.QuestID, claimedQuest.ID)
+				return nil, errorx.Unknown
+			}
+
+			ok, err := d.questRepo.IncreaseClaimedCount(ctx, quest.ID)
+			if err != nil {
+				xcontext.Logger(ctx).Errorf("Cannot increase claimed count: %v", err)
+				return nil, errorx.Unknown
+			}
+
+			if !ok {
+				return nil, errorx.New(errorx.Unavailable, "Quest %s has no remaining slots", quest.Title)
+			}
+
+			claimedQuest.Status = entity.Accepted
+			claimedQuest.OverriddenPoints = override.points
+			claimedQuest.RewardScale = override.rewardScale
+			if err := d.giveReward(ctx, quest, claimedQuest); err != nil {
+				return nil, err
+			}
+		}
+	case entity.Pending: // Unapprove
+		for _, claimedQuest := range claimedQuests {
+			if claimedQuest.Status != entity.Accepted {
+				continue
+			}
+
+			quest, ok := questInverse[claimedQuest.QuestID]
+			if !ok {
+				xcontext.Logger(ctx).Errorf(
+					"Not found quest %s of claimed quest %s", claimedQuest.QuestID, claimedQuest.ID)
+				return nil, errorx.Unknown
+			}
+
+			if err := d.questRepo.DecreaseClaimedCount(ctx, quest.ID); err != nil {
+				xcontext.Logger(ctx).Errorf("Cannot decrease claimed count: %v", err)
+				return nil, errorx.Unknown
+			}
+
+			if err := d.revertQuest(ctx, quest, claimedQuest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return common.MapKeys(leaseOwners), nil
+}
+
+// checkClaimLimitPerUser ensures that accepting the claimed quests doesn't make
+// any user exceed the max claims per user of quests. It must be called inside a
+// transaction.
+func (d *claimedQuestDomain) checkClaimLimitPerUser(
+	ctx context.Context, claimedQuests []entity.ClaimedQuest, quests map[string]entity.Quest,
+) error {
+	type questUser struct{ questID, userID string }
+
+	accepting := map[questUser]int{}
+	for _, cq := range claimedQuests {
+		if quests[cq.QuestID].MaxClaimsPerUser > 0 {
+			accepting[questUser{questID: cq.QuestID, userID: cq.UserID}]++
+		}
+	}
+
+	if len(accepting) == 0 {
+		return nil
+	}
+
+	// Lock followers in a fixed order to avoid deadlocks with other reviews.
+	userSet := map[string]string{}
+	for key := range accepting {
+		userSet[key.userID] = quests[key.questID].CommunityID.String
+	}
+
+	userIDs := common.MapKeys(userSet)
+	sort.Strings(userIDs)
+	for _, userID := range userIDs {
+		if err := d.lockFollower(ctx, userID, userSet[userID]); err != nil {
+			return err
+		}
+	}
+
+	for key, n := range accepting {
+		quest := quests[key.questID]
+		claims, err := d.claimedQuestRepo.CountUserClaims(ctx, key.questID, key.userID,
+			[]entity.ClaimedQuestStatus{entity.Accepted, entity.AutoAccepted})
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot count claims of user: %v", err)
+			return errorx.Unknown
+		}
+
+		if claims+int64(n) > int64(quest.MaxClaimsPerUser) {
+			return errorx.New(errorx.Unavailable,
+				"User %s has reached the maximum number of claims for quest %s", key.userID, quest.Title)
+		}
+	}
+
+	return nil
+}
+
+// lockFollower locks the follower row until the end of the transaction, it
+// serializes claims of the same user in a community.
+func (d *claimedQuestDomain) lockFollower(ctx context.Context, userID, communityID string) error {
+	_, err := d.followerRepo.GetForUpdate(ctx, userID, communityID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		xcontext.Logger(ctx).Errorf("Cannot lock follower: %v", err)
+		return errorx.Unknown
+	}
+
+	return nil
+}
+
+// releaseReviewLeases releases leases of reviewed claimed quests.
+func (d *claimedQuestDomain) releaseReviewLeases(ctx context.Context, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	leaseKeys := []string{}
+	for _, id := range ids {
+		leaseKeys = append(leaseKeys, common.RedisKeyReviewLease(id))
+	}
+
+	if err := d.redisClient.Del(ctx, leaseKeys...); err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot release leases of reviewed claimed quests: %v", err)
+	}
+}
+
+func (d *claimedQuestDomain) Appeal(
+	ctx context.Context, req *model.AppealClaimedQuestRequest,
+) (*model.AppealClaimedQuestResponse, error) {
+	if req.ID == "" {
+		return nil, errorx.New(errorx.BadRequest, "Not allow empty id")
+	}
+
+	if req.Message == "" {
+		return nil, errorx.New(errorx.BadRequest, "Not allow empty message")
+	}
+
+	claimedQuest, err := d.claimedQuestRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found claimed quest")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	requestUserID := xcontext.RequestUserID(ctx)
+	if claimedQuest.UserID != requestUserID {
+		return nil, errorx.New(errorx.PermissionDenied, "Only the claimer can appeal this claimed quest")
+	}
+
+	if claimedQuest.Status != entity.Rejected && claimedQuest.Status != entity.AutoRejected {
+		return nil, errorx.New(errorx.Unavailable, "Only allow to appeal a rejected claimed quest")
+	}
+
+	quest, err := d.questRepo.GetByID(ctx, claimedQuest.QuestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found quest")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if quest.Status != entity.QuestActive {
+		return nil, errorx.New(errorx.Unavailable, "Only allow to appeal a claimed quest of an active quest")
+	}
+
+	// An appealed claimed quest can be accepted later, so it must be in the
+	// current period of quest as a new claim.
+	if !questclaim.InCurrentRecurrence(quest.Recurrence, claimedQuest.CreatedAt) {
+		return nil, errorx.New(errorx.Unavailable, "Not allow to appeal a claimed quest of the previous period")
+	}
+
+	ctx = xcontext.WithDBTransaction(ctx)
+	defer xcontext.WithRollbackDBTransaction(ctx)
+
+	appeals, err := d.claimedQuestRepo.CountHistories(ctx, claimedQuest.ID, entity.Appealed)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot count appeals of claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if appeals > 0 {
+		return nil, errorx.New(errorx.Unavailable, "This claimed quest was appealed before")
+	}
+
+	if quest.MaxClaimsPerUser > 0 {
+		if err := d.lockFollower(ctx, requestUserID, quest.CommunityID.String); err != nil {
+			return nil, err
+		}
+	}
+
+	// The user may have claimed this quest again after being rejected.
+	activeStatuses := []entity.ClaimedQuestStatus{
+		entity.Pending, entity.Appealed, entity.Accepted, entity.AutoAccepted}
+	lastClaimedQuest, err := d.claimedQuestRepo.GetLast(ctx, repository.GetLastClaimedQuestFilter{
+		UserID:  requestUserID,
+		QuestID: quest.ID,
+		Status:  activeStatuses,
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		xcontext.Logger(ctx).Errorf("Cannot get the last claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if lastClaimedQuest != nil &&
+		questclaim.InCurrentRecurrence(quest.Recurrence, lastClaimedQuest.CreatedAt) {
+		return nil, errorx.New(errorx.Unavailable, "You have claimed this quest again")
+	}
+
+	if quest.MaxClaimsPerUser > 0 {
+		claims, err := d.claimedQuestRepo.CountUserClaims(ctx, quest.ID, requestUserID, activeStatuses)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot count claims of user: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if claims >= int64(quest.MaxClaimsPerUser) {
+			return nil, errorx.New(errorx.Unavailable, "You have reached the maximum number of claims for this quest")
+		}
+	}
+
+	if err := d.claimedQuestRepo.Appeal(ctx, claimedQuest.ID, req.SubmissionData); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.Unavailable, "Only allow to appeal a rejected claimed quest")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot appeal claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	err = d.claimedQuestRepo.CreateHistories(ctx, []entity.ClaimedQuestHistory{{
+		Base:           entity.Base{ID: uuid.NewString()},
+		ClaimedQuestID: claimedQuest.ID,
+		ActorID:        requestUserID,
+		Status:         entity.Appealed,
+		SubmissionData: req.SubmissionData,
+		Comment:        req.Message,
+	}})
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot create claimed quest history: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	xcontext.WithCommitDBTransaction(ctx)
+	return &model.AppealClaimedQuestResponse{}, nil
+}
+
+func (d *claimedQuestDomain) Update(
+	ctx context.Context, req *model.UpdateClaimedQuestRequest,
+) (*model.UpdateClaimedQuestResponse, error) {
+	if req.ID == "" {
+		return nil, errorx.New(errorx.BadRequest, "Not allow empty id")
+	}
+
+	claimedQuest, err := d.claimedQuestRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found claimed quest")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	requestUserID := xcontext.RequestUserID(ctx)
+	if claimedQuest.UserID != requestUserID {
+		return nil, errorx.New(errorx.PermissionDenied, "Only the claimer can update this claimed quest")
+	}
+
+	if claimedQuest.Status != entity.Pending {
+		return nil, errorx.New(errorx.Unavailable, "Only allow to update a pending claimed quest")
+	}
+
+	// The reviewer who is leasing this claimed quest would review a submission
+	// which is different from the one they saw.
+	leaseOwners, err := d.getLeaseOwners(ctx, []string{claimedQuest.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := leaseOwners[claimedQuest.ID]; ok {
+		return nil, errorx.New(errorx.Unavailable, "This claimed quest is being reviewed, please try again later")
+	}
+
+	quest, err := d.questRepo.GetByID(ctx, claimedQuest.QuestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found quest")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	// The new submission may be auto accepted if the quest has been changed
+	// to be auto validated since the user claimed it.
+	processor, err := d.questFactory.LoadProcessor(ctx, true, *quest, quest.ValidationData)
+	if err != nil {
+		return nil, err
+	}
+
+	req.SubmissionData = questclaim.NormalizeSubmissionData(processor, req.SubmissionData)
+	actionForClaim, err := processor.GetActionForClaim(
+		questclaim.WithClaimWalletAddress(ctx, claimedQuest.WalletAddress), req.SubmissionData)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rejected submission is not applied, so the user still keeps the
+	// pending one.
+	if actionForClaim.Is(questclaim.Rejected) {
+		message := actionForClaim.Message()
+		if message == "" {
+			message = "The new submission is rejected"
+		}
+
+		return nil, errorx.New(errorx.Unavailable, message)
+	}
+
+	claimedQuest.SubmissionData = req.SubmissionData
+	if actionForClaim.Is(questclaim.Accepted) {
+		// The quest must still be claimable to be accepted.
+		if quest.Status != entity.QuestActive {
+			return nil, errorx.New(errorx.Unavailable, "Only allow to claim active quests")
+		}
+
+		if reason := questclaim.CheckSchedule(*quest); reason != nil {
+			return nil, errorx.New(errorx.Unavailable, reason.Message)
+		}
+
+		claimedQuest.Status = entity.AutoAccepted
+		claimedQuest.ReviewedAt = sql.NullTime{Valid: true, Time: time.Now()}
+	}
+
+	ctx = xcontext.WithDBTransaction(ctx)
 	defer xcontext.WithRollbackDBTransaction(ctx)
 
+	if err := d.claimedQuestRepo.UpdatePendingSubmission(ctx, claimedQuest.ID, claimedQuest); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.Unavailable, "Only allow to update a pending claimed quest")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot update submission of claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	// Reviewers can see previous submissions via the history.
+	err = d.claimedQuestRepo.CreateHistories(ctx, []entity.ClaimedQuestHistory{{
+		Base:           entity.Base{ID: uuid.NewString()},
+		ClaimedQuestID: claimedQuest.ID,
+		ActorID:        requestUserID,
+		Status:         claimedQuest.Status,
+		SubmissionData: req.SubmissionData,
+	}})
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot create claimed quest history: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if claimedQuest.Status == entity.AutoAccepted {
+		ok, err := d.questRepo.IncreaseClaimedCount(ctx, quest.ID)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot increase claimed count: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if !ok {
+			return nil, errorx.New(errorx.Unavailable, "This quest has no remaining slots")
+		}
+
+		if err := d.giveReward(ctx, *quest, *claimedQuest); err != nil {
+			return nil, err
+		}
+	}
+
+	xcontext.WithCommitDBTransaction(ctx)
+
+	// The claimed quest is no longer leased if it was auto accepted.
+	if claimedQuest.Status == entity.AutoAccepted {
+		d.releaseReviewLeases(ctx, []string{claimedQuest.ID})
+	}
+
+	return &model.UpdateClaimedQuestResponse{
+		Status:  string(claimedQuest.Status),
+		Message: actionForClaim.Message(),
+	}, nil
+}
+
+func (d *claimedQuestDomain) Lease(
+	ctx context.Context, req *model.LeaseClaimedQuestsRequest,
+) (*model.LeaseClaimedQuestsResponse, error) {
+	community, err := d.communityRepo.GetByHandle(ctx, req.CommunityHandle)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found community")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get community: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if err := d.roleVerifier.Verify(ctx, community.ID); err != nil {
+		xcontext.Logger(ctx).Debugf("Permission denied: %v", err)
+		return nil, errorx.New(errorx.PermissionDenied, "Permission denied")
+	}
+
+	apiCfg := xcontext.Configs(ctx).ApiServer
+	if req.Limit == 0 {
+		req.Limit = apiCfg.DefaultLimit
+	}
+
+	if req.Limit < 0 {
+		return nil, errorx.New(errorx.BadRequest, "Limit must be positive")
+	}
+
+	if req.Limit > apiCfg.MaxLimit {
+		return nil, errorx.New(errorx.BadRequest, "Exceed the maximum of limit (%d)", apiCfg.MaxLimit)
+	}
+
+	leases, err := d.getReviewLeases(ctx, community.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	requestUserID := xcontext.RequestUserID(ctx)
-	err = d.claimedQuestRepo.UpdateReviewByIDs(
-		ctx, common.MapKeys(claimedQuestSet),
-		&entity.ClaimedQuest{
-			Status:     reviewAction,
-			Comment:    comment,
-			ReviewerID: requestUserID,
-			ReviewedAt: sql.NullTime{Valid: true, Time: time.Now()},
-		},
-	)
+	excludeIDs := []string{}
+	for id, reviewerID := range leases {
+		if reviewerID != requestUserID {
+			excludeIDs = append(excludeIDs, id)
+		}
+	}
+
+	claimedQuests, err := d.claimedQuestRepo.GetList(ctx, &repository.ClaimedQuestFilter{
+		CommunityID: community.ID,
+		QuestIDs:    req.QuestIDs,
+		Status:      []entity.ClaimedQuestStatus{entity.Pending, entity.Appealed},
+		ExcludeIDs:  excludeIDs,
+		Offset:      0,
+		Limit:       req.Limit,
+	})
 	if err != nil {
-		xcontext.Logger(ctx).Errorf("Unable to update status: %v", err)
-		return errorx.New(errorx.Internal, "Unable to update status for claim quest")
+		xcontext.Logger(ctx).Errorf("Cannot get claimed quests: %v", err)
+		return nil, errorx.Unknown
 	}
 
-	switch reviewAction {
-	case entity.Accepted:
-		for _, claimedQuest := range claimedQuests {
-			quest, ok := questInverse[claimedQuest.QuestID]
-			if !ok {
-				xcontext.Logger(ctx).Errorf(
-					"Not found quest %s of claimed quest %s", claimedQuest.QuestID, claimedQuest.ID)
-				return errorx.Unknown
+	timeout := xcontext.Configs(ctx).Quest.ReviewLeaseTimeout
+	leasedClaimedQuests := []entity.ClaimedQuest{}
+	newLeaseIDs := []string{}
+	for _, cq := range claimedQuests {
+		key := common.RedisKeyReviewLease(cq.ID)
+		if leases[cq.ID] == requestUserID {
+			// Renew the lease of this reviewer.
+			if err := d.redisClient.Expire(ctx, key, timeout); err != nil {
+				xcontext.Logger(ctx).Errorf("Cannot renew lease: %v", err)
+				return nil, errorx.Unknown
 			}
 
-			claimedQuest.Status = entity.Accepted
-			if err := d.giveReward(ctx, quest, claimedQuest); err != nil {
-				return err
-			}
+			leasedClaimedQuests = append(leasedClaimedQuests, cq)
+			continue
 		}
-	case entity.Pending: // Unapprove
-		for _, claimedQuest := range claimedQuests {
-			if claimedQuest.Status != entity.Accepted {
-				continue
-			}
 
-			quest, ok := questInverse[claimedQuest.QuestID]
-			if !ok {
-				xcontext.Logger(ctx).Errorf(
-					"Not found quest %s of claimed quest %s", claimedQuest.QuestID, claimedQuest.ID)
-				return errorx.Unknown
-			}
+		ok, err := d.redisClient.SetNX(ctx, key, requestUserID, timeout)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot lease claimed quest: %v", err)
+			return nil, errorx.Unknown
+		}
 
-			if err := d.revertQuest(ctx, quest, claimedQuest); err != nil {
-				return err
-			}
+		// Another reviewer has just leased this claimed quest.
+		if !ok {
+			continue
 		}
+
+		leasedClaimedQuests = append(leasedClaimedQuests, cq)
+		newLeaseIDs = append(newLeaseIDs, cq.ID)
 	}
 
-	xcontext.WithCommitDBTransaction(ctx)
-	return nil
+	if len(newLeaseIDs) > 0 {
+		err := d.redisClient.SAdd(ctx, common.RedisKeyReviewLeases(community.ID), newLeaseIDs...)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot add leases to community: %v", err)
+			return nil, errorx.Unknown
+		}
+	}
+
+	clientClaimedQuests, err := d.convertClaimedQuests(ctx, community.ID, leasedClaimedQuests)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.LeaseClaimedQuestsResponse{
+		ClaimedQuests: clientClaimedQuests,
+		ExpiredAt:     time.Now().Add(timeout).Format(model.DefaultTimeLayout),
+	}, nil
+}
+
+func (d *claimedQuestDomain) Release(
+	ctx context.Context, req *model.ReleaseClaimedQuestsRequest,
+) (*model.ReleaseClaimedQuestsResponse, error) {
+	if len(req.IDs) == 0 {
+		return nil, errorx.New(errorx.BadRequest, "Not allow empty id")
+	}
+
+	leaseOwners, err := d.getLeaseOwners(ctx, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	leaseKeys := []string{}
+	for id, reviewerID := range leaseOwners {
+		if reviewerID != xcontext.RequestUserID(ctx) {
+			return nil, errorx.New(errorx.PermissionDenied, "Claimed quest %s is leased by another reviewer", id)
+		}
+
+		leaseKeys = append(leaseKeys, common.RedisKeyReviewLease(id))
+	}
+
+	if len(leaseKeys) > 0 {
+		if err := d.redisClient.Del(ctx, leaseKeys...); err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot release leases: %v", err)
+			return nil, errorx.Unknown
+		}
+	}
+
+	return &model.ReleaseClaimedQuestsResponse{}, nil
+}
+
+func (d *claimedQuestDomain) GetReviewerStatistic(
+	ctx context.Context, req *model.GetReviewerStatisticRequest,
+) (*model.GetReviewerStatisticResponse, error) {
+	community, err := d.communityRepo.GetByHandle(ctx, req.CommunityHandle)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found community")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get community: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	ownerFollowerRole, err := d.followerRoleRepo.GetFirstByRole(ctx, community.ID, entity.OwnerBaseRole)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get owner role: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if ownerFollowerRole.UserID != xcontext.RequestUserID(ctx) {
+		return nil, errorx.New(errorx.PermissionDenied, "Only community owner can get reviewer statistic")
+	}
+
+	if req.Period == "" {
+		req.Period = "all"
+	}
+
+	period, err := statistic.ToPeriod(req.Period)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Invalid period: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid period")
+	}
+
+	stats, err := d.claimedQuestRepo.ReviewerStatistic(ctx, community.ID, period.Start(), period.End())
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get reviewer statistic: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	leases, err := d.getReviewLeases(ctx, community.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	leasing := map[string]int{}
+	for _, reviewerID := range leases {
+		leasing[reviewerID]++
+	}
+
+	reviewerIDs := []string{}
+	for _, s := range stats {
+		reviewerIDs = append(reviewerIDs, s.ReviewerID)
+	}
+
+	users, err := d.userRepo.GetByIDs(ctx, reviewerIDs)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get reviewers: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	userMap := map[string]*entity.User{}
+	for i := range users {
+		userMap[users[i].ID] = &users[i]
+	}
+
+	reviewers := []model.ReviewerStatistic{}
+	for _, s := range stats {
+		user, ok := userMap[s.ReviewerID]
+		if !ok {
+			xcontext.Logger(ctx).Warnf("Not found reviewer %s", s.ReviewerID)
+			continue
+		}
+
+		reviewers = append(reviewers, model.ReviewerStatistic{
+			Reviewer:   model.ConvertShortUser(user, ""),
+			Accepted:   s.Accepted,
+			Rejected:   s.Rejected,
+			Unapproved: s.Unapproved,
+			Leasing:    leasing[s.ReviewerID],
+		})
+	}
+
+	return &model.GetReviewerStatisticResponse{Reviewers: reviewers}, nil
+}
+
+// getLeaseOwners returns the reviewer who is leasing each claimed quest. Claimed
+// quests which are not leased are not included.
+func (d *claimedQuestDomain) getLeaseOwners(ctx context.Context, ids []string) (map[string]string, error) {
+	owners := map[string]string{}
+	if len(ids) == 0 {
+		return owners, nil
+	}
+
+	keys := []string{}
+	for _, id := range ids {
+		keys = append(keys, common.RedisKeyReviewLease(id))
+	}
+
+	values, err := d.redisClient.MGet(ctx, keys...)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get leases: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	for i, value := range values {
+		if reviewerID, ok := value.(string); ok {
+			owners[ids[i]] = reviewerID
+		}
+	}
+
+	return owners, nil
+}
+
+// getReviewLeases returns the reviewer who is leasing each claimed quest of the
+// community. Expired leases are removed from the community lease set.
+func (d *claimedQuestDomain) getReviewLeases(ctx context.Context, communityID string) (map[string]string, error) {
+	key := common.RedisKeyReviewLeases(communityID)
+	ids, err := d.redisClient.SMembers(ctx, key, 0)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get leases of community: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	owners, err := d.getLeaseOwners(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	expiredIDs := []string{}
+	for _, id := range ids {
+		if _, ok := owners[id]; !ok {
+			expiredIDs = append(expiredIDs, id)
+		}
+	}
+
+	if len(expiredIDs) > 0 {
+		if err := d.redisClient.SRem(ctx, key, expiredIDs...); err != nil {
+			xcontext.Logger(ctx).Warnf("Cannot remove expired leases: %v", err)
+		}
+	}
+
+	return owners, nil
 }
 
 func (d *claimedQuestDomain) GivePoint(
@@ -852,6 +2218,12 @@ func (d *claimedQuestDomain) giveReward(
 	claimedQuest entity.ClaimedQuest,
 ) error {
 	for _, data := range quest.Rewards {
+		// Coin and NFT rewards of raffle quests are only paid to winners when
+		// the quest ends.
+		if quest.RaffleWinners > 0 && (data.Type == entity.CoinReward || data.Type == entity.NFTReward) {
+			continue
+		}
+
 		reward, err := d.questFactory.LoadReward(ctx, quest.CommunityID.String, data.Type, data.Data)
 		if err != nil {
 			xcontext.Logger(ctx).Warnf("Invalid reward data: %v", err)
@@ -864,8 +2236,9 @@ func (d *claimedQuestDomain) giveReward(
 		}
 	}
 
+	points := claimedQuestPoints(quest, claimedQuest)
 	err := d.followerRepo.IncreasePoint(
-		ctx, claimedQuest.UserID, quest.CommunityID.String, quest.Points, true)
+		ctx, claimedQuest.UserID, quest.CommunityID.String, points, true)
 	if err != nil {
 		xcontext.Logger(ctx).Errorf("Cannot increase points for user: %v", err)
 		return errorx.Unknown
@@ -912,7 +2285,7 @@ func (d *claimedQuestDomain) giveReward(
 		return err
 	}
 
-	err = d.leaderboard.ChangePointLeaderboard(ctx, int64(quest.Points), reviewedAt, userID, communityID)
+	err = d.leaderboard.ChangePointLeaderboard(ctx, int64(points), reviewedAt, userID, communityID)
 	if err != nil {
 		return err
 	}
@@ -925,8 +2298,27 @@ func (d *claimedQuestDomain) revertQuest(
 	quest entity.Quest,
 	claimedQuest entity.ClaimedQuest,
 ) error {
+	for _, data := range quest.Rewards {
+		reward, err := d.questFactory.LoadReward(ctx, quest.CommunityID.String, data.Type, data.Data)
+		if err != nil {
+			xcontext.Logger(ctx).Warnf("Invalid reward data: %v", err)
+			continue
+		}
+
+		revocableReward, ok := reward.(questclaim.RevocableReward)
+		if !ok {
+			continue
+		}
+
+		revocableReward.WithClaimedQuest(&claimedQuest)
+		if err := revocableReward.Revoke(ctx); err != nil {
+			return err
+		}
+	}
+
+	points := claimedQuestPoints(quest, claimedQuest)
 	err := d.followerRepo.DecreasePoint(
-		ctx, claimedQuest.UserID, quest.CommunityID.String, quest.Points, true)
+		ctx, claimedQuest.UserID, quest.CommunityID.String, points, true)
 	if err != nil {
 		xcontext.Logger(ctx).Errorf("Unable to complete quest for user: %v", err)
 		return errorx.Unknown
@@ -941,10 +2333,126 @@ func (d *claimedQuestDomain) revertQuest(
 		return err
 	}
 
-	err = d.leaderboard.ChangePointLeaderboard(ctx, -int64(quest.Points), reviewedAt, userID, communityID)
+	err = d.leaderboard.ChangePointLeaderboard(ctx, -int64(points), reviewedAt, userID, communityID)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// claimedQuestPoints returns the points given to the user who claimed the
+// quest, it is the overridden points of reviewer if any.
+func claimedQuestPoints(quest entity.Quest, claimedQuest entity.ClaimedQuest) uint64 {
+	if claimedQuest.OverriddenPoints.Valid {
+		return uint64(claimedQuest.OverriddenPoints.Int64)
+	}
+
+	return quest.Points
+}
+
+func (d *claimedQuestDomain) GetSurveyResult(
+	ctx context.Context, req *model.GetSurveyResultRequest,
+) (*model.GetSurveyResultResponse, error) {
+	quest, err := d.questRepo.GetByID(ctx, req.QuestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found quest")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if err := d.roleVerifier.Verify(ctx, quest.CommunityID.String); err != nil {
+		xcontext.Logger(ctx).Debugf("Permission denied: %v", err)
+		return nil, errorx.New(errorx.PermissionDenied, "Permission denied")
+	}
+
+	apiCfg := xcontext.Configs(ctx).ApiServer
+	if req.Limit == 0 {
+		req.Limit = apiCfg.DefaultLimit
+	}
+
+	if req.Limit < 0 {
+		return nil, errorx.New(errorx.BadRequest, "Limit must be positive")
+	}
+
+	if req.Limit > apiCfg.MaxLimit {
+		return nil, errorx.New(errorx.BadRequest, "Exceed the maximum of limit (%d)", apiCfg.MaxLimit)
+	}
+
+	questions, err := d.questFactory.LoadSurvey(ctx, *quest)
+	if err != nil {
+		return nil, err
+	}
+
+	respondents, err := d.surveyAnswerRepo.CountRespondents(ctx, quest.ID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot count respondents of survey: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	statistic, err := d.surveyAnswerRepo.Statistic(ctx, quest.ID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get statistic of survey: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	results := []model.SurveyQuestionResult{}
+	for _, q := range questions {
+		results = append(results, model.SurveyQuestionResult{
+			Question:  q.Question,
+			Type:      q.Type,
+			Options:   q.Options,
+			MaxRating: q.MaxRating,
+			Required:  q.Required,
+			Answers:   []model.SurveyAnswerCount{},
+		})
+	}
+
+	for _, r := range respondents {
+		if r.Question < len(results) {
+			results[r.Question].Respondents = r.Count
+		}
+	}
+
+	ratingSum := make([]int64, len(results))
+	ratingCount := make([]int64, len(results))
+	for _, stat := range statistic {
+		if stat.Question >= len(results) {
+			continue
+		}
+
+		result := &results[stat.Question]
+		switch entity.SurveyQuestionType(result.Type) {
+		case entity.SurveyText:
+			if len(result.Answers) >= req.Limit {
+				continue
+			}
+
+		case entity.SurveyRating:
+			rating, err := strconv.Atoi(stat.Answer)
+			if err != nil {
+				xcontext.Logger(ctx).Warnf("Invalid rating in database: %v", err)
+				continue
+			}
+
+			ratingSum[stat.Question] += int64(rating) * stat.Count
+			ratingCount[stat.Question] += stat.Count
+		}
+
+		result.Answers = append(result.Answers, model.SurveyAnswerCount{
+			Answer: stat.Answer,
+			Count:  stat.Count,
+		})
+	}
+
+	for i := range results {
+		if ratingCount[i] > 0 {
+			results[i].AverageRating = float64(ratingSum[i]) / float64(ratingCount[i])
+		}
+	}
+
+	return &model.GetSurveyResultResponse{Questions: results}, nil
+}
@@ -3,11 +3,17 @@ package domain
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fatih/structs"
 	"github.com/questx-lab/backend/internal/common"
 	"github.com/questx-lab/backend/internal/domain/badge"
 	"github.com/questx-lab/backend/internal/entity"
@@ -54,6 +60,8 @@ func Test_claimedQuestDomain_Claim_AutoText(t *testing.T) {
 		userRepo,
 		communityRepo,
 		categoryRepo,
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
 		badge.NewManager(
 			badgeRepo,
 			badgeDetailRepo,
@@ -96,6 +104,238 @@ func Test_claimedQuestDomain_Claim_AutoText(t *testing.T) {
 	require.Equal(t, "recurrence", err.Error())
 }
 
+func Test_claimedQuestDomain_Claim_MaxClaims(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+	badgeDetailRepo := repository.NewBadgeDetailRepository()
+
+	limitedQuest := &entity.Quest{
+		Base:           entity.Base{ID: "limited quest"},
+		CommunityID:    sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:           entity.QuestText,
+		Status:         entity.QuestActive,
+		Recurrence:     entity.Once,
+		ValidationData: entity.Map{"auto_validate": true, "answer": "Foo"},
+		ConditionOp:    entity.Or,
+		MaxClaims:      1,
+	}
+
+	err := questRepo.Create(ctx, limitedQuest)
+	require.NoError(t, err)
+
+	d := NewClaimedQuestDomain(
+		repository.NewClaimedQuestRepository(),
+		questRepo,
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			badgeDetailRepo,
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	// User1 takes the only slot of the quest.
+	resp, err := d.Claim(xcontext.WithRequestUserID(ctx, testutil.User1.ID), &model.ClaimQuestRequest{
+		QuestID:        limitedQuest.ID,
+		SubmissionData: "Foo",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "auto_accepted", resp.Status)
+
+	quest, err := questRepo.GetByID(ctx, limitedQuest.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, quest.ClaimedCount)
+
+	// Updating the quest must not override the claimed count.
+	require.NoError(t, questRepo.Save(ctx, limitedQuest))
+	quest, err = questRepo.GetByID(ctx, limitedQuest.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, quest.ClaimedCount)
+
+	// User2 cannot claim the quest anymore.
+	_, err = d.Claim(xcontext.WithRequestUserID(ctx, testutil.User2.ID), &model.ClaimQuestRequest{
+		QuestID:        limitedQuest.ID,
+		SubmissionData: "Foo",
+	})
+	require.Equal(t, errorx.New(errorx.Unavailable, "This quest has no remaining slots"), err)
+}
+
+func Test_claimedQuestDomain_Claim_BadgeReward(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+	badgeDetailRepo := repository.NewBadgeDetailRepository()
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	reward, err := questFactory.NewReward(ctx, testutil.Community1.ID, entity.BadgeReward,
+		map[string]any{"name": "early bird", "icon_url": "https://example.com/early-bird.png"})
+	require.NoError(t, err)
+
+	badgeQuest := &entity.Quest{
+		Base:           entity.Base{ID: "badge quest"},
+		CommunityID:    sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:           entity.QuestText,
+		Status:         entity.QuestActive,
+		Recurrence:     entity.Once,
+		ValidationData: entity.Map{"auto_validate": true, "answer": "Foo"},
+		ConditionOp:    entity.Or,
+		Rewards:        entity.Array[entity.Reward]{{Type: entity.BadgeReward, Data: structs.Map(reward)}},
+	}
+	require.NoError(t, questRepo.Create(ctx, badgeQuest))
+
+	d := NewClaimedQuestDomain(
+		repository.NewClaimedQuestRepository(),
+		questRepo,
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			badgeDetailRepo,
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		questFactory,
+		testutil.RedisClient(ctx),
+	)
+
+	authorizedCtx := xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	resp, err := d.Claim(authorizedCtx, &model.ClaimQuestRequest{
+		QuestID:        badgeQuest.ID,
+		SubmissionData: "Foo",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "auto_accepted", resp.Status)
+
+	badgeDetail, err := badgeDetailRepo.GetLatest(ctx, testutil.User1.ID, testutil.Community1.ID, "early bird")
+	require.NoError(t, err)
+
+	earlyBird, err := badgeRepo.GetByID(ctx, badgeDetail.BadgeID)
+	require.NoError(t, err)
+	require.Equal(t, 1, earlyBird.Level)
+	require.Equal(t, testutil.Community1.ID, earlyBird.CommunityID.String)
+
+	// Another community can define a badge with the same name and level, it is
+	// a different badge.
+	anotherReward, err := questFactory.NewReward(ctx, testutil.Community2.ID, entity.BadgeReward,
+		map[string]any{"name": "early bird"})
+	require.NoError(t, err)
+
+	anotherReward.WithClaimedQuest(&entity.ClaimedQuest{UserID: testutil.User2.ID})
+	require.NoError(t, anotherReward.Give(ctx))
+
+	badgeDetail, err = badgeDetailRepo.GetLatest(ctx, testutil.User2.ID, testutil.Community2.ID, "early bird")
+	require.NoError(t, err)
+
+	anotherEarlyBird, err := badgeRepo.GetByID(ctx, badgeDetail.BadgeID)
+	require.NoError(t, err)
+	require.NotEqual(t, earlyBird.ID, anotherEarlyBird.ID)
+	require.Equal(t, testutil.Community2.ID, anotherEarlyBird.CommunityID.String)
+
+	// Names of scanned badges are reserved.
+	_, err = questFactory.NewReward(ctx, testutil.Community2.ID, entity.BadgeReward,
+		map[string]any{"name": badge.SharpScoutBadgeName})
+	require.Equal(t, errorx.New(errorx.Unavailable, "Badge name %s is reserved", badge.SharpScoutBadgeName), err)
+}
+
+func Test_claimedQuestDomain_Review_MaxClaimsPerUser(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+	badgeDetailRepo := repository.NewBadgeDetailRepository()
+
+	limitedQuest := &entity.Quest{
+		Base:             entity.Base{ID: "limited quest"},
+		CommunityID:      sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Title:            "Daily check-in",
+		Type:             entity.QuestText,
+		Status:           entity.QuestActive,
+		Recurrence:       entity.Daily,
+		ValidationData:   entity.Map{},
+		ConditionOp:      entity.Or,
+		MaxClaimsPerUser: 1,
+	}
+	require.NoError(t, questRepo.Create(ctx, limitedQuest))
+
+	// User2 has been accepted once, then the pending claim must not be
+	// accepted anymore.
+	for _, cq := range []*entity.ClaimedQuest{
+		{Base: entity.Base{ID: "accepted"}, Status: entity.Accepted},
+		{Base: entity.Base{ID: "pending"}, Status: entity.Pending},
+	} {
+		cq.QuestID = limitedQuest.ID
+		cq.UserID = testutil.User2.ID
+		require.NoError(t, claimedQuestRepo.Create(ctx, cq))
+	}
+
+	d := NewClaimedQuestDomain(
+		claimedQuestRepo,
+		questRepo,
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			badgeDetailRepo,
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	ctx = xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	ctx = xcontext.WithHTTPRequest(ctx, httptest.NewRequest("GET", "/review", nil))
+	_, err := d.Review(ctx, &model.ReviewRequest{IDs: []string{"pending"}, Action: string(entity.Accepted)})
+	require.Equal(t, errorx.New(errorx.Unavailable,
+		"User user2 has reached the maximum number of claims for quest Daily check-in"), err)
+
+	claimedQuest, err := claimedQuestRepo.GetByID(ctx, "pending")
+	require.NoError(t, err)
+	require.Equal(t, entity.Pending, claimedQuest.Status)
+
+	// The pending claim can be accepted after the accepted one is unapproved.
+	_, err = d.Review(ctx, &model.ReviewRequest{IDs: []string{"accepted"}, Action: string(entity.Pending)})
+	require.NoError(t, err)
+
+	_, err = d.Review(ctx, &model.ReviewRequest{IDs: []string{"pending"}, Action: string(entity.Accepted)})
+	require.NoError(t, err)
+}
+
 func Test_claimedQuestDomain_Claim_GivePoint(t *testing.T) {
 	ctx := testutil.MockContext(t)
 	testutil.CreateFixtureDb(ctx)
@@ -131,6 +371,8 @@ func Test_claimedQuestDomain_Claim_GivePoint(t *testing.T) {
 		userRepo,
 		communityRepo,
 		categoryRepo,
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
 		badge.NewManager(
 			badgeRepo,
 			badgeDetailRepo,
@@ -212,6 +454,8 @@ func Test_claimedQuestDomain_Claim_ManualText(t *testing.T) {
 		userRepo,
 		communityRepo,
 		categoryRepo,
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
 		badge.NewManager(
 			badgeRepo,
 			badgeDetailRepo,
@@ -244,6 +488,96 @@ func Test_claimedQuestDomain_Claim_ManualText(t *testing.T) {
 	require.Equal(t, "recurrence", err.Error())
 }
 
+func Test_claimedQuestDomain_Claim_Survey(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+
+	surveyQuest := &entity.Quest{
+		Base:        entity.Base{ID: "survey quest"},
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:        entity.QuestSurvey,
+		Status:      entity.QuestActive,
+		Recurrence:  entity.Once,
+		ValidationData: entity.Map{
+			"questions": []map[string]any{
+				{"question": "Q1", "type": "single_choice", "options": []string{"A", "B"}, "required": true},
+				{"question": "Q2", "type": "multiple_choice", "options": []string{"A", "B", "C"}},
+				{"question": "Q3", "type": "rating", "max_rating": 5, "required": true},
+				{"question": "Q4", "type": "text"},
+			},
+		},
+		ConditionOp: entity.Or,
+	}
+
+	err := questRepo.Create(ctx, surveyQuest)
+	require.NoError(t, err)
+
+	d := NewClaimedQuestDomain(
+		repository.NewClaimedQuestRepository(),
+		questRepo,
+		repository.NewFollowerRepository(),
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			repository.NewBadgeRepository(),
+			repository.NewBadgeDetailRepository(),
+			badge.NewRainBowBadgeScanner(repository.NewBadgeRepository(), repository.NewFollowerRepository()),
+			badge.NewQuestWarriorBadgeScanner(repository.NewBadgeRepository(), repository.NewFollowerRepository()),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	// User2 cannot skip a required question.
+	_, err = d.Claim(xcontext.WithRequestUserID(ctx, testutil.User2.ID), &model.ClaimQuestRequest{
+		QuestID:        surveyQuest.ID,
+		SubmissionData: `{"answers": [["A"], [], [], []]}`,
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.BadRequest, "Question 3 is required"))
+
+	resp, err := d.Claim(xcontext.WithRequestUserID(ctx, testutil.User2.ID), &model.ClaimQuestRequest{
+		QuestID:        surveyQuest.ID,
+		SubmissionData: `{"answers": [["A"], ["A", "C"], ["4"], ["good"]]}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "auto_accepted", resp.Status)
+
+	resp, err = d.Claim(xcontext.WithRequestUserID(ctx, testutil.User3.ID), &model.ClaimQuestRequest{
+		QuestID:        surveyQuest.ID,
+		SubmissionData: `{"answers": [["A"], ["C"], ["5"], []]}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "auto_accepted", resp.Status)
+
+	req := httptest.NewRequest("GET", "/getSurveyResult", nil)
+	ownerCtx := xcontext.WithHTTPRequest(xcontext.WithRequestUserID(ctx, testutil.User1.ID), req)
+	result, err := d.GetSurveyResult(ownerCtx, &model.GetSurveyResultRequest{QuestID: surveyQuest.ID})
+	require.NoError(t, err)
+	require.Len(t, result.Questions, 4)
+
+	require.Equal(t, int64(2), result.Questions[0].Respondents)
+	require.Equal(t, []model.SurveyAnswerCount{{Answer: "A", Count: 2}}, result.Questions[0].Answers)
+
+	require.Equal(t, int64(2), result.Questions[1].Respondents)
+	require.Equal(t, []model.SurveyAnswerCount{
+		{Answer: "C", Count: 2},
+		{Answer: "A", Count: 1},
+	}, result.Questions[1].Answers)
+
+	require.Equal(t, 4.5, result.Questions[2].AverageRating)
+
+	require.Equal(t, int64(1), result.Questions[3].Respondents)
+	require.Equal(t, []model.SurveyAnswerCount{{Answer: "good", Count: 1}}, result.Questions[3].Answers)
+}
+
 func Test_claimedQuestDomain_Claim(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -290,6 +624,8 @@ func Test_claimedQuestDomain_Claim(t *testing.T) {
 				repository.NewUserRepository(testutil.RedisClient(tt.args.ctx)),
 				repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(tt.args.ctx)),
 				repository.NewCategoryRepository(),
+				repository.NewSurveyAnswerRepository(),
+				repository.NewOAuth2Repository(),
 				badge.NewManager(repository.NewBadgeRepository(), repository.NewBadgeDetailRepository()),
 				&testutil.MockLeaderboard{},
 				testutil.NewCommunityRoleVerifier(tt.args.ctx),
@@ -641,6 +977,7 @@ func Test_claimedQuestDomain_GetList(t *testing.T) {
 					repository.NewRoleRepository(),
 					repository.NewUserRepository(testutil.RedisClient(tt.args.ctx)),
 				),
+				redisClient: testutil.RedisClient(tt.args.ctx),
 			}
 
 			req := httptest.NewRequest("GET", "/getClaimedQuest", nil)
@@ -689,7 +1026,7 @@ func Test_claimedQuestDomain_Review(t *testing.T) {
 					Action: string(entity.Accepted),
 				},
 			},
-			wantErr: errorx.New(errorx.BadRequest, "Claimed quest claimedQuest1 must be pending"),
+			wantErr: errorx.New(errorx.BadRequest, "Claimed quest claimedQuest1 must be pending or appealed"),
 		},
 		{
 			name: "permission denied",
@@ -714,6 +1051,8 @@ func Test_claimedQuestDomain_Review(t *testing.T) {
 				repository.NewUserRepository(testutil.RedisClient(tt.args.ctx)),
 				repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(tt.args.ctx)),
 				repository.NewCategoryRepository(),
+				repository.NewSurveyAnswerRepository(),
+				repository.NewOAuth2Repository(),
 				badge.NewManager(
 					repository.NewBadgeRepository(),
 					repository.NewBadgeDetailRepository(),
@@ -878,6 +1217,8 @@ func Test_claimedQuestDomain_ReviewAll(t *testing.T) {
 				repository.NewUserRepository(testutil.RedisClient(ctx)),
 				repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
 				repository.NewCategoryRepository(),
+				repository.NewSurveyAnswerRepository(),
+				repository.NewOAuth2Repository(),
 				badge.NewManager(
 					repository.NewBadgeRepository(),
 					repository.NewBadgeDetailRepository(),
@@ -925,7 +1266,7 @@ func Test_fullScenario_Review_Unapprove(t *testing.T) {
 		followerRoleRepo,
 		userRepo,
 		communityRepo,
-		categoryRepo, nil,
+		categoryRepo, repository.NewSurveyAnswerRepository(), repository.NewOAuth2Repository(), nil,
 		&testutil.MockLeaderboard{},
 		testutil.NewCommunityRoleVerifier(ctx),
 		nil,
@@ -986,3 +1327,850 @@ func Test_fullScenario_Review_Unapprove(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorIs(t, err, errorx.New(errorx.BadRequest, "Claimed quest claimedQuest3 must be accepted or rejected"))
 }
+
+func Test_fullScenario_RoleReward(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	followerRepo := repository.NewFollowerRepository()
+	followerRoleRepo := repository.NewFollowerRoleRepository()
+	badgeRepo := repository.NewBadgeRepository()
+	badgeDetailRepo := repository.NewBadgeDetailRepository()
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	ambassadorRole := &entity.Role{
+		Base:        entity.Base{ID: "community1_ambassador"},
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Name:        "ambassador",
+	}
+	require.NoError(t, repository.NewRoleRepository().Create(ctx, ambassadorRole))
+
+	_, err := questFactory.NewReward(ctx, testutil.Community1.ID, entity.RoleReward,
+		map[string]any{"role_id": testutil.Role5.ID})
+	require.Equal(t, errorx.New(errorx.Unavailable, "Only roles without permissions can be given as reward"), err)
+
+	reward, err := questFactory.NewReward(ctx, testutil.Community1.ID, entity.RoleReward,
+		map[string]any{"role_id": ambassadorRole.ID})
+	require.NoError(t, err)
+
+	roleQuest := &entity.Quest{
+		Base:           entity.Base{ID: "role quest"},
+		CommunityID:    sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:           entity.QuestText,
+		Status:         entity.QuestActive,
+		Recurrence:     entity.Once,
+		ValidationData: entity.Map{"auto_validate": false},
+		ConditionOp:    entity.Or,
+		Rewards:        entity.Array[entity.Reward]{{Type: entity.RoleReward, Data: structs.Map(reward)}},
+	}
+	require.NoError(t, questRepo.Create(ctx, roleQuest))
+
+	d := NewClaimedQuestDomain(
+		claimedQuestRepo,
+		questRepo,
+		followerRepo,
+		followerRoleRepo,
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			badgeDetailRepo,
+			badge.NewSharpScoutBadgeScanner(badgeRepo, followerRepo),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		questFactory,
+		testutil.RedisClient(ctx),
+	)
+
+	resp, err := d.Claim(xcontext.WithRequestUserID(ctx, testutil.User3.ID), &model.ClaimQuestRequest{
+		QuestID:        roleQuest.ID,
+		SubmissionData: "I want to be an ambassador",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", resp.Status)
+
+	hasAmbassadorRole := func() bool {
+		followerRoles, err := followerRoleRepo.Get(ctx, testutil.User3.ID, testutil.Community1.ID)
+		require.NoError(t, err)
+
+		for _, followerRole := range followerRoles {
+			if followerRole.RoleID == ambassadorRole.ID {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	reviewCtx := xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	reviewCtx = xcontext.WithHTTPRequest(reviewCtx, httptest.NewRequest("GET", "/review", nil))
+
+	// The role is given to user after the claimed quest is accepted.
+	_, err = d.Review(reviewCtx, &model.ReviewRequest{Action: string(entity.Accepted), IDs: []string{resp.ID}})
+	require.NoError(t, err)
+	require.True(t, hasAmbassadorRole())
+
+	// And it is revoked after unapproving.
+	_, err = d.Review(reviewCtx, &model.ReviewRequest{Action: string(entity.Pending), IDs: []string{resp.ID}})
+	require.NoError(t, err)
+	require.False(t, hasAmbassadorRole())
+
+	// The role is assigned to user manually, it is not granted by the claimed
+	// quest anymore.
+	require.NoError(t, followerRoleRepo.Create(ctx, &entity.FollowerRole{
+		UserID:      testutil.User3.ID,
+		CommunityID: testutil.Community1.ID,
+		RoleID:      ambassadorRole.ID,
+	}))
+
+	_, err = d.Review(reviewCtx, &model.ReviewRequest{Action: string(entity.Accepted), IDs: []string{resp.ID}})
+	require.NoError(t, err)
+	require.True(t, hasAmbassadorRole())
+
+	// So it is kept after unapproving.
+	_, err = d.Review(reviewCtx, &model.ReviewRequest{Action: string(entity.Pending), IDs: []string{resp.ID}})
+	require.NoError(t, err)
+	require.True(t, hasAmbassadorRole())
+}
+
+func Test_fullScenario_Review_OverridePoints(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+
+	d := NewClaimedQuestDomain(
+		claimedQuestRepo,
+		repository.NewQuestRepository(&testutil.MockSearchCaller{}),
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			repository.NewBadgeDetailRepository(),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	ctx = xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	ctx = xcontext.WithHTTPRequest(ctx, httptest.NewRequest("GET", "/review", nil))
+
+	points := uint64(30)
+	rewardScale := 0.5
+
+	// Cannot override points when rejecting.
+	_, err := d.Review(ctx, &model.ReviewRequest{
+		Action: string(entity.Rejected),
+		IDs:    []string{testutil.ClaimedQuest3.ID},
+		Points: &points,
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.BadRequest, "Only allow to override points or rewards when accepting"))
+
+	_, err = d.Review(ctx, &model.ReviewRequest{
+		Action:      string(entity.Accepted),
+		IDs:         []string{testutil.ClaimedQuest3.ID},
+		Points:      &points,
+		RewardScale: &rewardScale,
+	})
+	require.NoError(t, err)
+
+	claimedQuest, err := claimedQuestRepo.GetByID(ctx, testutil.ClaimedQuest3.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(points), claimedQuest.OverriddenPoints.Int64)
+	require.Equal(t, rewardScale, claimedQuest.RewardScale.Float64)
+
+	// User receives the overridden points instead of the quest's points.
+	follower, err := followerRepo.Get(ctx, testutil.User3.ID, testutil.Community1.ID)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Followers[4].Points+points, follower.Points)
+
+	stats, err := claimedQuestRepo.Statistic(ctx, repository.StatisticClaimedQuestFilter{
+		UserID: testutil.User3.ID,
+		Status: []entity.ClaimedQuestStatus{entity.Accepted},
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, points, stats[0].Points)
+
+	// Unapproving reverts the overridden points.
+	_, err = d.Review(ctx, &model.ReviewRequest{
+		Action: string(entity.Pending),
+		IDs:    []string{testutil.ClaimedQuest3.ID},
+	})
+	require.NoError(t, err)
+
+	follower, err = followerRepo.Get(ctx, testutil.User3.ID, testutil.Community1.ID)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Followers[4].Points, follower.Points)
+}
+
+func Test_fullScenario_Appeal(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+
+	d := NewClaimedQuestDomain(
+		claimedQuestRepo,
+		repository.NewQuestRepository(&testutil.MockSearchCaller{}),
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			repository.NewBadgeDetailRepository(),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	claimerCtx := xcontext.WithRequestUserID(ctx, testutil.ClaimedQuest2.UserID)
+	reviewerCtx := xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	reviewerCtx = xcontext.WithHTTPRequest(reviewerCtx, httptest.NewRequest("GET", "/review", nil))
+
+	// Only the claimer can appeal.
+	_, err := d.Appeal(reviewerCtx, &model.AppealClaimedQuestRequest{
+		ID:      testutil.ClaimedQuest2.ID,
+		Message: "please",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.PermissionDenied, "Only the claimer can appeal this claimed quest"))
+
+	// Cannot appeal a non-rejected claimed quest.
+	_, err = d.Appeal(xcontext.WithRequestUserID(ctx, testutil.ClaimedQuest3.UserID), &model.AppealClaimedQuestRequest{
+		ID:      testutil.ClaimedQuest3.ID,
+		Message: "please",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.Unavailable, "Only allow to appeal a rejected claimed quest"))
+
+	_, err = d.Appeal(claimerCtx, &model.AppealClaimedQuestRequest{
+		ID:             testutil.ClaimedQuest2.ID,
+		Message:        "I did it, here is the proof",
+		SubmissionData: "new evidence",
+	})
+	require.NoError(t, err)
+
+	// Reviewers can see the appealed claimed quest.
+	resp, err := d.GetList(reviewerCtx, &model.GetListClaimedQuestRequest{
+		CommunityHandle: testutil.Community1.Handle,
+		Status:          string(entity.Appealed),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.ClaimedQuests, 1)
+	require.Equal(t, testutil.ClaimedQuest2.ID, resp.ClaimedQuests[0].ID)
+	require.Equal(t, "new evidence", resp.ClaimedQuests[0].SubmissionData)
+
+	_, err = d.Review(reviewerCtx, &model.ReviewRequest{
+		Action:  string(entity.Rejected),
+		Comment: "still not enough",
+		IDs:     []string{testutil.ClaimedQuest2.ID},
+	})
+	require.NoError(t, err)
+
+	// A claimed quest can be appealed only once.
+	_, err = d.Appeal(claimerCtx, &model.AppealClaimedQuestRequest{
+		ID:      testutil.ClaimedQuest2.ID,
+		Message: "please again",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.Unavailable, "This claimed quest was appealed before"))
+
+	claimedQuest, err := d.Get(reviewerCtx, &model.GetClaimedQuestRequest{ID: testutil.ClaimedQuest2.ID})
+	require.NoError(t, err)
+	require.Equal(t, string(entity.Rejected), claimedQuest.Status)
+	require.Len(t, claimedQuest.History, 2)
+	require.Equal(t, string(entity.Appealed), claimedQuest.History[0].Status)
+	require.Equal(t, "I did it, here is the proof", claimedQuest.History[0].Comment)
+	require.Equal(t, string(entity.Rejected), claimedQuest.History[1].Status)
+	require.Equal(t, "still not enough", claimedQuest.History[1].Comment)
+}
+
+func Test_fullScenario_Appeal_QuestState(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+
+	d := NewClaimedQuestDomain(
+		claimedQuestRepo,
+		questRepo,
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			repository.NewBadgeDetailRepository(),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	newQuest := func(id string, status entity.QuestStatusType, recurrence entity.RecurrenceType, maxClaimsPerUser int) {
+		require.NoError(t, questRepo.Create(ctx, &entity.Quest{
+			Base:             entity.Base{ID: id},
+			CommunityID:      testutil.Quest1.CommunityID,
+			Type:             entity.QuestText,
+			Status:           status,
+			Recurrence:       recurrence,
+			ValidationData:   entity.Map{"auto_validate": false},
+			ConditionOp:      entity.Or,
+			MaxClaimsPerUser: maxClaimsPerUser,
+		}))
+	}
+
+	newClaimedQuest := func(id, questID string, status entity.ClaimedQuestStatus, createdAt time.Time) {
+		require.NoError(t, claimedQuestRepo.Create(ctx, &entity.ClaimedQuest{
+			Base:    entity.Base{ID: id, CreatedAt: createdAt},
+			QuestID: questID,
+			UserID:  testutil.User3.ID,
+			Status:  status,
+		}))
+	}
+
+	appeal := func(id string) error {
+		_, err := d.Appeal(xcontext.WithRequestUserID(ctx, testutil.User3.ID), &model.AppealClaimedQuestRequest{
+			ID:      id,
+			Message: "please",
+		})
+		return err
+	}
+
+	// Cannot appeal if the quest is not active anymore.
+	newQuest("archived quest", entity.QuestArchived, entity.Once, 0)
+	newClaimedQuest("rejected of archived quest", "archived quest", entity.Rejected, time.Now())
+	require.ErrorIs(t, appeal("rejected of archived quest"),
+		errorx.New(errorx.Unavailable, "Only allow to appeal a claimed quest of an active quest"))
+
+	// Cannot appeal a claimed quest of the previous period.
+	newQuest("daily quest", entity.QuestActive, entity.Daily, 0)
+	newClaimedQuest("old rejected of daily quest", "daily quest", entity.Rejected, time.Now().Add(-48*time.Hour))
+	require.ErrorIs(t, appeal("old rejected of daily quest"),
+		errorx.New(errorx.Unavailable, "Not allow to appeal a claimed quest of the previous period"))
+
+	// Cannot appeal if the user claimed the quest again after being rejected.
+	newQuest("once quest", entity.QuestActive, entity.Once, 0)
+	newClaimedQuest("rejected of once quest", "once quest", entity.Rejected, time.Now().Add(-time.Hour))
+	newClaimedQuest("accepted of once quest", "once quest", entity.Accepted, time.Now())
+	require.ErrorIs(t, appeal("rejected of once quest"),
+		errorx.New(errorx.Unavailable, "You have claimed this quest again"))
+
+	// Cannot appeal if the user has reached the maximum number of claims.
+	newQuest("limited quest", entity.QuestActive, entity.Daily, 1)
+	newClaimedQuest("old accepted of limited quest", "limited quest", entity.Accepted, time.Now().Add(-48*time.Hour))
+	newClaimedQuest("rejected of limited quest", "limited quest", entity.Rejected, time.Now())
+	require.ErrorIs(t, appeal("rejected of limited quest"),
+		errorx.New(errorx.Unavailable, "You have reached the maximum number of claims for this quest"))
+
+	// The appeal is accepted for a claimed quest of the current period.
+	newClaimedQuest("rejected of daily quest", "daily quest", entity.Rejected, time.Now())
+	require.NoError(t, appeal("rejected of daily quest"))
+}
+
+func Test_fullScenario_LeaseClaimedQuests(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+
+	d := NewClaimedQuestDomain(
+		repository.NewClaimedQuestRepository(),
+		repository.NewQuestRepository(&testutil.MockSearchCaller{}),
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			repository.NewBadgeDetailRepository(),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	reviewerCtx := func(userID, path string) context.Context {
+		ctx := xcontext.WithRequestUserID(ctx, userID)
+		return xcontext.WithHTTPRequest(ctx, httptest.NewRequest("GET", path, nil))
+	}
+
+	// User1 leases all pending claimed quests of community.
+	leaseResp, err := d.Lease(reviewerCtx(testutil.User1.ID, "/leaseClaimedQuests"), &model.LeaseClaimedQuestsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+	})
+	require.NoError(t, err)
+	require.Len(t, leaseResp.ClaimedQuests, 1)
+	require.Equal(t, testutil.ClaimedQuest3.ID, leaseResp.ClaimedQuests[0].ID)
+
+	// Another reviewer cannot lease, see, or review it.
+	leaseResp, err = d.Lease(reviewerCtx(testutil.User3.ID, "/leaseClaimedQuests"), &model.LeaseClaimedQuestsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+	})
+	require.NoError(t, err)
+	require.Len(t, leaseResp.ClaimedQuests, 0)
+
+	listResp, err := d.GetList(reviewerCtx(testutil.User3.ID, "/getClaimedQuests"), &model.GetListClaimedQuestRequest{
+		CommunityHandle: testutil.Community1.Handle,
+		Status:          string(entity.Pending),
+	})
+	require.NoError(t, err)
+	require.Len(t, listResp.ClaimedQuests, 0)
+
+	_, err = d.Review(reviewerCtx(testutil.User3.ID, "/review"), &model.ReviewRequest{
+		Action: string(entity.Accepted),
+		IDs:    []string{testutil.ClaimedQuest3.ID},
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.Unavailable,
+		"Claimed quest claimedQuest3 is being reviewed by another reviewer"))
+
+	_, err = d.Release(reviewerCtx(testutil.User3.ID, "/releaseClaimedQuests"), &model.ReleaseClaimedQuestsRequest{
+		IDs: []string{testutil.ClaimedQuest3.ID},
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.PermissionDenied,
+		"Claimed quest claimedQuest3 is leased by another reviewer"))
+
+	// The lease owner reviews it, then the lease is released.
+	_, err = d.Review(reviewerCtx(testutil.User1.ID, "/review"), &model.ReviewRequest{
+		Action: string(entity.Accepted),
+		IDs:    []string{testutil.ClaimedQuest3.ID},
+	})
+	require.NoError(t, err)
+
+	exist, err := testutil.RedisClient(ctx).Exist(ctx, common.RedisKeyReviewLease(testutil.ClaimedQuest3.ID))
+	require.NoError(t, err)
+	require.False(t, exist)
+
+	// Only the owner can see reviewer statistic.
+	_, err = d.GetReviewerStatistic(reviewerCtx(testutil.User3.ID, "/getReviewerStatistic"),
+		&model.GetReviewerStatisticRequest{CommunityHandle: testutil.Community1.Handle})
+	require.ErrorIs(t, err, errorx.New(errorx.PermissionDenied, "Only community owner can get reviewer statistic"))
+
+	statResp, err := d.GetReviewerStatistic(reviewerCtx(testutil.User1.ID, "/getReviewerStatistic"),
+		&model.GetReviewerStatisticRequest{CommunityHandle: testutil.Community1.Handle, Period: "week"})
+	require.NoError(t, err)
+	require.Len(t, statResp.Reviewers, 1)
+	require.Equal(t, testutil.User1.ID, statResp.Reviewers[0].Reviewer.ID)
+	require.Equal(t, uint64(1), statResp.Reviewers[0].Accepted)
+	require.Equal(t, uint64(0), statResp.Reviewers[0].Rejected)
+}
+
+func Test_claimedQuestDomain_Export(t *testing.T) {
+	ctx := testutil.MockContextWithUserID(t, testutil.User1.ID)
+	testutil.CreateFixtureDb(ctx)
+	ctx = xcontext.WithHTTPRequest(ctx, httptest.NewRequest("GET", "/exportClaimedQuests", nil))
+
+	d := NewClaimedQuestDomain(
+		repository.NewClaimedQuestRepository(),
+		repository.NewQuestRepository(&testutil.MockSearchCaller{}),
+		repository.NewFollowerRepository(),
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		nil,
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	recorder := httptest.NewRecorder()
+	err := d.Export(xcontext.WithHTTPWriter(ctx, recorder), &model.ExportClaimedQuestsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+
+	records, err := csv.NewReader(recorder.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(testutil.ClaimedQuests)+1)
+	require.Equal(t, exportClaimedQuestCSVHeader, records[0])
+	require.Equal(t, testutil.ClaimedQuest1.ID, records[1][0])
+	require.Equal(t, testutil.Quest1.Title, records[1][2])
+	require.Equal(t, testutil.User1.Name, records[1][4])
+
+	recorder = httptest.NewRecorder()
+	err = d.Export(xcontext.WithHTTPWriter(ctx, recorder), &model.ExportClaimedQuestsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+		Status:          string(entity.Rejected),
+		Format:          "ndjson",
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var row model.ExportedClaimedQuest
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+	require.Equal(t, testutil.ClaimedQuest2.ID, row.ID)
+	require.Equal(t, string(entity.Rejected), row.Status)
+
+	// Nothing is written if the request is invalid.
+	recorder = httptest.NewRecorder()
+	err = d.Export(xcontext.WithHTTPWriter(ctx, recorder), &model.ExportClaimedQuestsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+		Status:          "unknown",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.BadRequest, "Invalid status filter"))
+	require.Empty(t, recorder.Header().Get("Content-Type"))
+	require.Zero(t, recorder.Body.Len())
+
+	// Batches are paginated by (created_at, id), claimed quests created at the
+	// same time are neither skipped nor duplicated.
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	createdAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	for _, id := range []string{"same time b", "same time a"} {
+		require.NoError(t, claimedQuestRepo.Create(ctx, &entity.ClaimedQuest{
+			Base:    entity.Base{ID: id, CreatedAt: createdAt},
+			QuestID: testutil.Quest1.ID,
+			UserID:  testutil.User2.ID,
+			Status:  entity.Pending,
+		}))
+	}
+
+	claimedQuests, err := claimedQuestRepo.GetList(ctx, &repository.ClaimedQuestFilter{
+		CommunityID:    testutil.Community1.ID,
+		Limit:          1,
+		AfterCreatedAt: createdAt,
+		AfterID:        "same time a",
+	})
+	require.NoError(t, err)
+	require.Len(t, claimedQuests, 1)
+	require.Equal(t, "same time b", claimedQuests[0].ID)
+}
+
+func Test_claimedQuestDomain_ReviewByFile(t *testing.T) {
+	ctx := testutil.MockContextWithUserID(t, testutil.User1.ID)
+	testutil.CreateFixtureDb(ctx)
+
+	body := &strings.Builder{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("community_handle", testutil.Community1.Handle))
+	file, err := writer.CreateFormFile("file", "decisions.csv")
+	require.NoError(t, err)
+	_, err = file.Write([]byte(strings.Join([]string{
+		"id,action,comment,points",
+		testutil.ClaimedQuest3.ID + ",rejected,not enough",
+		testutil.ClaimedQuest1.ID + ",accepted,,10",
+		testutil.ClaimedQuest3.ID + ",accepted",
+		"unknown-id,rejected",
+		testutil.ClaimedQuest2.ID + ",approve",
+	}, "\n")))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	httpReq := httptest.NewRequest("POST", "/reviewByFile", strings.NewReader(body.String()))
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	ctx = xcontext.WithHTTPRequest(ctx, httpReq)
+	ctx = xcontext.WithHTTPWriter(ctx, httptest.NewRecorder())
+
+	d := NewClaimedQuestDomain(
+		repository.NewClaimedQuestRepository(),
+		repository.NewQuestRepository(&testutil.MockSearchCaller{}),
+		repository.NewFollowerRepository(),
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		nil,
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	resp, err := d.ReviewByFile(ctx, &model.ReviewByFileRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Succeeded)
+	require.Equal(t, 4, resp.Failed)
+	require.Equal(t, []model.ReviewByFileResult{
+		{Row: 2, ID: testutil.ClaimedQuest3.ID, Action: "rejected", Success: true},
+		{
+			Row: 3, ID: testutil.ClaimedQuest1.ID, Action: "accepted",
+			Error: "Claimed quest " + testutil.ClaimedQuest1.ID + " must be pending or appealed",
+		},
+		{Row: 4, ID: testutil.ClaimedQuest3.ID, Action: "accepted", Error: "Duplicated with row 2"},
+		{Row: 5, ID: "unknown-id", Action: "rejected", Error: "Not found claimed quest in community"},
+		{Row: 6, ID: testutil.ClaimedQuest2.ID, Action: "approve", Error: "Invalid action"},
+	}, resp.Results)
+
+	claimedQuest, err := repository.NewClaimedQuestRepository().GetByID(ctx, testutil.ClaimedQuest3.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.Rejected, claimedQuest.Status)
+	require.Equal(t, "not enough", claimedQuest.Comment)
+
+	claimedQuest, err = repository.NewClaimedQuestRepository().GetByID(ctx, testutil.ClaimedQuest1.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.Accepted, claimedQuest.Status)
+	require.False(t, claimedQuest.OverriddenPoints.Valid)
+}
+
+func Test_fullScenario_UpdateClaimedQuest(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	followerRepo := repository.NewFollowerRepository()
+	badgeRepo := repository.NewBadgeRepository()
+
+	d := NewClaimedQuestDomain(
+		claimedQuestRepo,
+		repository.NewQuestRepository(&testutil.MockSearchCaller{}),
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			repository.NewBadgeDetailRepository(),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		testutil.NewQuestFactory(ctx),
+		testutil.RedisClient(ctx),
+	)
+
+	// Only the claimer can update the submission.
+	_, err := d.Update(xcontext.WithRequestUserID(ctx, testutil.User1.ID), &model.UpdateClaimedQuestRequest{
+		ID:             testutil.ClaimedQuest3.ID,
+		SubmissionData: "https://example.com",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.PermissionDenied, "Only the claimer can update this claimed quest"))
+
+	claimerCtx := xcontext.WithRequestUserID(ctx, testutil.ClaimedQuest3.UserID)
+	reviewerCtx := xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	reviewerCtx = xcontext.WithHTTPRequest(reviewerCtx, httptest.NewRequest("GET", "/leaseClaimedQuests", nil))
+
+	// Cannot update the submission while a reviewer is leasing it.
+	_, err = d.Lease(reviewerCtx, &model.LeaseClaimedQuestsRequest{CommunityHandle: testutil.Community1.Handle})
+	require.NoError(t, err)
+
+	_, err = d.Update(claimerCtx, &model.UpdateClaimedQuestRequest{
+		ID:             testutil.ClaimedQuest3.ID,
+		SubmissionData: "https://example.com",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.Unavailable, "This claimed quest is being reviewed, please try again later"))
+
+	_, err = d.Release(reviewerCtx, &model.ReleaseClaimedQuestsRequest{IDs: []string{testutil.ClaimedQuest3.ID}})
+	require.NoError(t, err)
+
+	// Cannot auto accept the new submission if the quest has ended.
+	require.NoError(t, xcontext.DB(ctx).Model(&entity.Quest{}).
+		Where("id=?", testutil.ClaimedQuest3.QuestID).
+		Update("end_at", time.Now().Add(-time.Minute)).Error)
+
+	_, err = d.Update(claimerCtx, &model.UpdateClaimedQuestRequest{
+		ID:             testutil.ClaimedQuest3.ID,
+		SubmissionData: "https://example.com",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.Unavailable, "This quest has ended"))
+
+	require.NoError(t, xcontext.DB(ctx).Model(&entity.Quest{}).
+		Where("id=?", testutil.ClaimedQuest3.QuestID).
+		Update("end_at", nil).Error)
+
+	// The visit link quest accepts the new submission automatically.
+	resp, err := d.Update(claimerCtx, &model.UpdateClaimedQuestRequest{
+		ID:             testutil.ClaimedQuest3.ID,
+		SubmissionData: "https://example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(entity.AutoAccepted), resp.Status)
+
+	claimedQuest, err := claimedQuestRepo.GetByID(ctx, testutil.ClaimedQuest3.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.AutoAccepted, claimedQuest.Status)
+	require.Equal(t, "https://example.com", claimedQuest.SubmissionData)
+	require.True(t, claimedQuest.ReviewedAt.Valid)
+
+	follower, err := followerRepo.Get(ctx, testutil.ClaimedQuest3.UserID, testutil.Community1.ID)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Followers[4].Points+testutil.Quest2.Points, follower.Points)
+
+	histories, err := claimedQuestRepo.GetHistories(ctx, testutil.ClaimedQuest3.ID)
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	require.Equal(t, testutil.ClaimedQuest3.UserID, histories[0].ActorID)
+	require.Equal(t, entity.AutoAccepted, histories[0].Status)
+	require.Equal(t, "https://example.com", histories[0].SubmissionData)
+
+	// The claimed quest is no longer pending.
+	_, err = d.Update(claimerCtx, &model.UpdateClaimedQuestRequest{
+		ID:             testutil.ClaimedQuest3.ID,
+		SubmissionData: "https://example.org",
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.Unavailable, "Only allow to update a pending claimed quest"))
+}
+
+func Test_fullScenario_LotteryTicketReward(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	followerRepo := repository.NewFollowerRepository()
+	lotteryRepo := repository.NewLotteryRepository()
+	badgeRepo := repository.NewBadgeRepository()
+	communityRepo := repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx))
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	event := &entity.LotteryEvent{
+		Base:           entity.Base{ID: "lottery event"},
+		CommunityID:    testutil.Community1.ID,
+		StartTime:      time.Now().Add(-time.Hour),
+		EndTime:        time.Now().Add(time.Hour),
+		MaxTickets:     10,
+		PointPerTicket: 5000,
+	}
+	require.NoError(t, lotteryRepo.CreateEvent(ctx, event))
+
+	reward, err := questFactory.NewReward(ctx, testutil.Community1.ID, entity.LotteryReward,
+		map[string]any{"event_id": event.ID, "tickets": 2})
+	require.NoError(t, err)
+
+	ticketQuest := &entity.Quest{
+		Base:           entity.Base{ID: "ticket quest"},
+		CommunityID:    sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:           entity.QuestText,
+		Status:         entity.QuestActive,
+		Recurrence:     entity.Once,
+		ValidationData: entity.Map{"auto_validate": false},
+		ConditionOp:    entity.Or,
+		Rewards:        entity.Array[entity.Reward]{{Type: entity.LotteryReward, Data: structs.Map(reward)}},
+	}
+	require.NoError(t, questRepo.Create(ctx, ticketQuest))
+
+	d := NewClaimedQuestDomain(
+		claimedQuestRepo,
+		questRepo,
+		followerRepo,
+		repository.NewFollowerRoleRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		communityRepo,
+		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
+		badge.NewManager(
+			badgeRepo,
+			repository.NewBadgeDetailRepository(),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		nil,
+		questFactory,
+		testutil.RedisClient(ctx),
+	)
+
+	lotteryDomain := NewLotteryDomain(
+		lotteryRepo,
+		followerRepo,
+		communityRepo,
+		nil,
+		testutil.NewCommunityRoleVerifier(ctx),
+		questFactory,
+		&testutil.MockBlockchainCaller{},
+	)
+
+	userCtx := xcontext.WithRequestUserID(ctx, testutil.User3.ID)
+	resp, err := d.Claim(userCtx, &model.ClaimQuestRequest{
+		QuestID:        ticketQuest.ID,
+		SubmissionData: "give me tickets",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", resp.Status)
+
+	reviewCtx := xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	reviewCtx = xcontext.WithHTTPRequest(reviewCtx, httptest.NewRequest("GET", "/review", nil))
+
+	// The free tickets are given to user after the claimed quest is accepted.
+	_, err = d.Review(reviewCtx, &model.ReviewRequest{Action: string(entity.Accepted), IDs: []string{resp.ID}})
+	require.NoError(t, err)
+
+	freeTickets, err := lotteryRepo.GetFreeTickets(ctx, event.ID, testutil.User3.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, freeTickets)
+
+	// User doesn't have enough points, so the ticket is bought by a free ticket.
+	buyResp, err := lotteryDomain.BuyTicket(userCtx, &model.BuyLotteryTicketsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+		NumberTickets:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "", buyResp.Error)
+
+	freeTickets, err = lotteryRepo.GetFreeTickets(ctx, event.ID, testutil.User3.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, freeTickets)
+
+	// Only the unused ticket is taken back after unapproving.
+	_, err = d.Review(reviewCtx, &model.ReviewRequest{Action: string(entity.Pending), IDs: []string{resp.ID}})
+	require.NoError(t, err)
+
+	freeTickets, err = lotteryRepo.GetFreeTickets(ctx, event.ID, testutil.User3.ID)
+	require.NoError(t, err)
+	require.Equal(t, 0, freeTickets)
+
+	// User cannot buy tickets anymore.
+	buyResp, err = lotteryDomain.BuyTicket(userCtx, &model.BuyLotteryTicketsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+		NumberTickets:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Not enough point", buyResp.Error)
+}
@@ -0,0 +1,159 @@
+package cron
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/questx-lab/backend/internal/domain/questclaim"
+	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/internal/repository"
+	"github.com/questx-lab/backend/pkg/xcontext"
+)
+
+const raffleWinnerSavePoint = "raffle_winner"
+
+// QuestRaffleCronJob draws winners of raffle quests after they end, then pays
+// the deferred coin and NFT rewards to these winners.
+type QuestRaffleCronJob struct {
+	questRepo        repository.QuestRepository
+	claimedQuestRepo repository.ClaimedQuestRepository
+	questRaffleRepo  repository.QuestRaffleRepository
+	questFactory     questclaim.Factory
+}
+
+func NewQuestRaffleCronJob(
+	questRepo repository.QuestRepository,
+	claimedQuestRepo repository.ClaimedQuestRepository,
+	questRaffleRepo repository.QuestRaffleRepository,
+	questFactory questclaim.Factory,
+) *QuestRaffleCronJob {
+	return &QuestRaffleCronJob{
+		questRepo:        questRepo,
+		claimedQuestRepo: claimedQuestRepo,
+		questRaffleRepo:  questRaffleRepo,
+		questFactory:     questFactory,
+	}
+}
+
+func (job *QuestRaffleCronJob) Do(ctx context.Context) {
+	quests, err := job.questRepo.GetUndrawnRaffles(ctx, time.Now())
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get undrawn raffle quests: %v", err)
+		return
+	}
+
+	for _, quest := range quests {
+		if err := job.draw(ctx, quest, time.Now().UnixNano()); err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot draw raffle of quest %s: %v", quest.ID, err)
+			continue
+		}
+	}
+}
+
+func (job *QuestRaffleCronJob) draw(ctx context.Context, quest entity.Quest, seed int64) error {
+	claimedQuests, err := job.claimedQuestRepo.GetList(ctx, &repository.ClaimedQuestFilter{
+		QuestIDs: []string{quest.ID},
+		Status:   []entity.ClaimedQuestStatus{entity.Accepted, entity.AutoAccepted},
+		Offset:   0,
+		Limit:    -1,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The participants must be in a stable order, so the draw can be
+	// reproduced with the seed.
+	sort.Slice(claimedQuests, func(i, j int) bool {
+		if !claimedQuests[i].CreatedAt.Equal(claimedQuests[j].CreatedAt) {
+			return claimedQuests[i].CreatedAt.Before(claimedQuests[j].CreatedAt)
+		}
+
+		return claimedQuests[i].ID < claimedQuests[j].ID
+	})
+
+	rand.New(rand.NewSource(seed)).Shuffle(len(claimedQuests), func(i, j int) {
+		claimedQuests[i], claimedQuests[j] = claimedQuests[j], claimedQuests[i]
+	})
+
+	ctx = xcontext.WithDBTransaction(ctx)
+	defer xcontext.WithRollbackDBTransaction(ctx)
+
+	raffle := &entity.QuestRaffle{
+		QuestID:               quest.ID,
+		Seed:                  seed,
+		Participants:          len(claimedQuests),
+		WinnerClaimedQuestIDs: entity.Array[string]{},
+		WinnerUserIDs:         entity.Array[string]{},
+	}
+
+	for _, claimedQuest := range claimedQuests {
+		if len(raffle.WinnerClaimedQuestIDs) >= quest.RaffleWinners {
+			break
+		}
+
+		// A participant who cannot receive the rewards (e.g. no wallet address)
+		// should not prevent other winners from receiving theirs, the next
+		// participant is drawn instead.
+		if err := xcontext.DB(ctx).SavePoint(raffleWinnerSavePoint).Error; err != nil {
+			return err
+		}
+
+		if err := job.giveRewards(ctx, quest, claimedQuest); err != nil {
+			xcontext.Logger(ctx).Warnf("Cannot give raffle rewards to claimed quest %s: %v", claimedQuest.ID, err)
+			if err := xcontext.DB(ctx).RollbackTo(raffleWinnerSavePoint).Error; err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		raffle.WinnerClaimedQuestIDs = append(raffle.WinnerClaimedQuestIDs, claimedQuest.ID)
+		raffle.WinnerUserIDs = append(raffle.WinnerUserIDs, claimedQuest.UserID)
+	}
+
+	raffle.Winners = len(raffle.WinnerClaimedQuestIDs)
+	raffle.DrawnAt = time.Now()
+
+	// The raffle is recorded in the same transaction with pay rewards, so the
+	// rewards can never be paid twice.
+	if err := job.questRaffleRepo.Create(ctx, raffle); err != nil {
+		return err
+	}
+
+	xcontext.WithCommitDBTransaction(ctx)
+	return nil
+}
+
+func (job *QuestRaffleCronJob) giveRewards(
+	ctx context.Context, quest entity.Quest, winner entity.ClaimedQuest,
+) error {
+	ctx = xcontext.WithRequestUserID(ctx, winner.UserID)
+	for _, data := range quest.Rewards {
+		if data.Type != entity.CoinReward && data.Type != entity.NFTReward {
+			continue
+		}
+
+		reward, err := job.questFactory.LoadReward(ctx, quest.CommunityID.String, data.Type, data.Data)
+		if err != nil {
+			xcontext.Logger(ctx).Warnf("Invalid reward data: %v", err)
+			continue
+		}
+
+		reward.WithClaimedQuest(&winner)
+		if err := reward.Give(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (job *QuestRaffleCronJob) RunNow() bool {
+	return true
+}
+
+func (job *QuestRaffleCronJob) Next() time.Time {
+	return time.Now().Add(time.Minute)
+}
@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/internal/repository"
+	"github.com/questx-lab/backend/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QuestRaffleCronJob(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	claimedQuestRepo := repository.NewClaimedQuestRepository()
+	questRaffleRepo := repository.NewQuestRaffleRepository()
+
+	raffleQuest := &entity.Quest{
+		Base:        entity.Base{ID: "raffle quest"},
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:        entity.QuestText,
+		Status:      entity.QuestActive,
+		Recurrence:  entity.Once,
+		ConditionOp: entity.Or,
+		EndAt:       sql.NullTime{Valid: true, Time: time.Now().Add(-time.Minute)},
+		Rewards: entity.Array[entity.Reward]{{
+			Type: entity.CoinReward,
+			Data: entity.Map{"amount": 100, "chain": "eth", "token_id": "usdt"},
+		}},
+		RaffleWinners: 2,
+	}
+	require.NoError(t, questRepo.Create(ctx, raffleQuest))
+
+	for _, userID := range []string{testutil.User1.ID, testutil.User2.ID} {
+		require.NoError(t, claimedQuestRepo.Create(ctx, &entity.ClaimedQuest{
+			Base:          entity.Base{ID: "raffle claimed quest of " + userID},
+			QuestID:       raffleQuest.ID,
+			UserID:        userID,
+			Status:        entity.AutoAccepted,
+			WalletAddress: "0x0000000000000000000000000000000000000001",
+		}))
+	}
+
+	// User3 has no wallet address, so they cannot receive the rewards if they are
+	// drawn. Another participant must be drawn instead.
+	require.NoError(t, claimedQuestRepo.Create(ctx, &entity.ClaimedQuest{
+		Base:    entity.Base{ID: "raffle claimed quest of " + testutil.User3.ID},
+		QuestID: raffleQuest.ID,
+		UserID:  testutil.User3.ID,
+		Status:  entity.AutoAccepted,
+	}))
+
+	job := NewQuestRaffleCronJob(questRepo, claimedQuestRepo, questRaffleRepo, testutil.NewQuestFactory(ctx))
+	job.Do(ctx)
+
+	raffle, err := questRaffleRepo.GetByQuestID(ctx, raffleQuest.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, raffle.Participants)
+	require.Equal(t, 2, raffle.Winners)
+	require.ElementsMatch(t, []string{testutil.User1.ID, testutil.User2.ID}, []string(raffle.WinnerUserIDs))
+	require.ElementsMatch(t, []string{
+		"raffle claimed quest of " + testutil.User1.ID,
+		"raffle claimed quest of " + testutil.User2.ID,
+	}, []string(raffle.WinnerClaimedQuestIDs))
+
+	payRewards, err := repository.NewPayRewardRepository().GetAllPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, payRewards, 2)
+
+	// The raffle is never drawn twice.
+	job.Do(ctx)
+	payRewards, err = repository.NewPayRewardRepository().GetAllPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, payRewards, 2)
+}
@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/internal/repository"
+	"github.com/questx-lab/backend/pkg/xcontext"
+)
+
+// QuestScheduleCronJob activates draft quests when they reach their start time
+// and archives active quests when they reach their end time. Drafts which were
+// activated before are not touched, they were moved back to draft on purpose.
+type QuestScheduleCronJob struct {
+	questRepo repository.QuestRepository
+}
+
+func NewQuestScheduleCronJob(questRepo repository.QuestRepository) *QuestScheduleCronJob {
+	return &QuestScheduleCronJob{questRepo: questRepo}
+}
+
+func (job *QuestScheduleCronJob) Do(ctx context.Context) {
+	now := time.Now()
+
+	startedQuests, err := job.questRepo.GetScheduled(ctx, repository.ScheduledQuestFilter{
+		Status:         entity.QuestDraft,
+		StartedBefore:  now,
+		NeverActivated: true,
+	})
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get started quests: %v", err)
+	} else {
+		job.changeStatus(ctx, startedQuests, entity.QuestDraft, entity.QuestActive)
+	}
+
+	endedQuests, err := job.questRepo.GetScheduled(ctx, repository.ScheduledQuestFilter{
+		Status:      entity.QuestActive,
+		EndedBefore: now,
+	})
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get ended quests: %v", err)
+	} else {
+		job.changeStatus(ctx, endedQuests, entity.QuestActive, entity.QuestArchived)
+	}
+}
+
+func (job *QuestScheduleCronJob) changeStatus(
+	ctx context.Context, quests []entity.Quest, from, to entity.QuestStatusType,
+) {
+	for _, quest := range quests {
+		// The status may be changed by the admin after the quest is queried,
+		// only change it if it's still the same.
+		ok, err := job.questRepo.UpdateStatus(ctx, quest.ID, from, to)
+		if err != nil {
+			xcontext.Logger(ctx).Warnf("Cannot change status of quest %s to %s: %v", quest.ID, to, err)
+			continue
+		}
+
+		if !ok {
+			xcontext.Logger(ctx).Debugf("Status of quest %s was changed, skip it", quest.ID)
+		}
+	}
+}
+
+func (job *QuestScheduleCronJob) RunNow() bool {
+	return true
+}
+
+func (job *QuestScheduleCronJob) Next() time.Time {
+	return time.Now().Add(time.Minute)
+}
@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/internal/repository"
+	"github.com/questx-lab/backend/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QuestScheduleCronJob(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+
+	startedQuest := &entity.Quest{
+		Base:        entity.Base{ID: "started quest"},
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:        entity.QuestText,
+		Status:      entity.QuestDraft,
+		Recurrence:  entity.Once,
+		ConditionOp: entity.Or,
+		StartAt:     sql.NullTime{Valid: true, Time: time.Now().Add(-time.Minute)},
+	}
+	require.NoError(t, questRepo.Create(ctx, startedQuest))
+
+	notStartedQuest := &entity.Quest{
+		Base:        entity.Base{ID: "not started quest"},
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:        entity.QuestText,
+		Status:      entity.QuestDraft,
+		Recurrence:  entity.Once,
+		ConditionOp: entity.Or,
+		StartAt:     sql.NullTime{Valid: true, Time: time.Now().Add(time.Hour)},
+	}
+	require.NoError(t, questRepo.Create(ctx, notStartedQuest))
+
+	// This quest was activated before, then moved back to draft by the admin.
+	redraftedQuest := &entity.Quest{
+		Base:        entity.Base{ID: "redrafted quest"},
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:        entity.QuestText,
+		Status:      entity.QuestDraft,
+		Recurrence:  entity.Once,
+		ConditionOp: entity.Or,
+		StartAt:     sql.NullTime{Valid: true, Time: time.Now().Add(-time.Hour)},
+		ActivatedAt: sql.NullTime{Valid: true, Time: time.Now().Add(-time.Minute)},
+	}
+	require.NoError(t, questRepo.Create(ctx, redraftedQuest))
+
+	endedQuest := &entity.Quest{
+		Base:        entity.Base{ID: "ended quest"},
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		Type:        entity.QuestText,
+		Status:      entity.QuestActive,
+		Recurrence:  entity.Once,
+		ConditionOp: entity.Or,
+		EndAt:       sql.NullTime{Valid: true, Time: time.Now().Add(-time.Minute)},
+	}
+	require.NoError(t, questRepo.Create(ctx, endedQuest))
+
+	NewQuestScheduleCronJob(questRepo).Do(ctx)
+
+	expected := map[string]entity.QuestStatusType{
+		startedQuest.ID:    entity.QuestActive,
+		notStartedQuest.ID: entity.QuestDraft,
+		redraftedQuest.ID:  entity.QuestDraft,
+		endedQuest.ID:      entity.QuestArchived,
+	}
+
+	for id, status := range expected {
+		quest, err := questRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, status, quest.Status, id)
+	}
+
+	quest, err := questRepo.GetByID(ctx, startedQuest.ID)
+	require.NoError(t, err)
+	require.True(t, quest.ActivatedAt.Valid)
+
+	// The admin moves the started quest back to draft, it must not be activated
+	// again.
+	quest.Status = entity.QuestDraft
+	require.NoError(t, questRepo.Save(ctx, quest))
+
+	NewQuestScheduleCronJob(questRepo).Do(ctx)
+	quest, err = questRepo.GetByID(ctx, startedQuest.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.QuestDraft, quest.Status)
+}
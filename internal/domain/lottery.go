@@ -271,8 +271,18 @@ func (d *lotteryDomain) GetLotteryEvent(
 		clientPrizes = append(clientPrizes, model.ConvertLotteryPrize(&prize))
 	}
 
+	freeTickets := 0
+	if userID := xcontext.RequestUserID(ctx); userID != "" {
+		freeTickets, err = d.lotteryRepo.GetFreeTickets(ctx, event.ID, userID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			xcontext.Logger(ctx).Errorf("Cannot get free tickets: %v", err)
+			return nil, errorx.Unknown
+		}
+	}
+
 	return &model.GetLotteryEventResponse{
-		Event: model.ConvertLotteryEvent(event, model.ConvertCommunity(community, 0), clientPrizes),
+		Event:       model.ConvertLotteryEvent(event, model.ConvertCommunity(community, 0), clientPrizes),
+		FreeTickets: freeTickets,
 	}, nil
 }
 
@@ -340,7 +350,17 @@ func (d *lotteryDomain) BuyTicket(
 				return "", err
 			}
 
-			if event.PointPerTicket > 0 {
+			// Prefer using free tickets before spending points.
+			usedFreeTicket := true
+			if err := d.lotteryRepo.UseFreeTicket(ctx, event.ID, userID); err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					return "", err
+				}
+
+				usedFreeTicket = false
+			}
+
+			if !usedFreeTicket && event.PointPerTicket > 0 {
 				err = d.followerRepo.DecreasePoint(ctx, userID, community.ID,
 					event.PointPerTicket, false)
 				if err != nil {
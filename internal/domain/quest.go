@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +32,8 @@ type QuestDomain interface {
 	Delete(context.Context, *model.DeleteQuestRequest) (*model.DeleteQuestResponse, error)
 	GetTemplates(context.Context, *model.GetQuestTemplatesRequest) (*model.GetQuestTemplatestResponse, error)
 	ParseTemplate(context.Context, *model.ParseQuestTemplatesRequest) (*model.ParseQuestTemplatestResponse, error)
+	Export(context.Context, *model.ExportQuestsRequest) (*model.ExportQuestsResponse, error)
+	Import(context.Context, *model.ImportQuestsRequest) (*model.ImportQuestsResponse, error)
 }
 
 type questDomain struct {
@@ -133,6 +136,18 @@ func (d *questDomain) Create(
 		return nil, errorx.New(errorx.BadRequest, "Invalid condition op %s", req.ConditionOp)
 	}
 
+	if err := setClaimLimit(quest, req.MaxClaims, req.MaxClaimsPerUser); err != nil {
+		return nil, err
+	}
+
+	if err := setSchedule(quest, req.StartAt, req.EndAt); err != nil {
+		return nil, err
+	}
+
+	if quest.Status == entity.QuestActive && !quest.ActivatedAt.Valid {
+		quest.ActivatedAt = sql.NullTime{Valid: true, Time: time.Now()}
+	}
+
 	if err != nil {
 		xcontext.Logger(ctx).Errorf("Cannot create quest factory with user: %v", err)
 		return nil, errorx.Unknown
@@ -153,6 +168,10 @@ func (d *questDomain) Create(
 		quest.Rewards = append(quest.Rewards, entity.Reward{Type: rType, Data: structs.Map(reward)})
 	}
 
+	if err := setRaffle(quest, req.RaffleWinners); err != nil {
+		return nil, err
+	}
+
 	for _, c := range req.Conditions {
 		ctype, err := enum.ToEnum[entity.ConditionType](c.Type)
 		if err != nil {
@@ -586,6 +605,18 @@ func (d *questDomain) Update(
 		return nil, errorx.New(errorx.BadRequest, "Invalid condition op %s", req.ConditionOp)
 	}
 
+	if err := setClaimLimit(quest, req.MaxClaims, req.MaxClaimsPerUser); err != nil {
+		return nil, err
+	}
+
+	if err := setSchedule(quest, req.StartAt, req.EndAt); err != nil {
+		return nil, err
+	}
+
+	if quest.Status == entity.QuestActive && !quest.ActivatedAt.Valid {
+		quest.ActivatedAt = sql.NullTime{Valid: true, Time: time.Now()}
+	}
+
 	for _, r := range req.Rewards {
 		rType, err := enum.ToEnum[entity.RewardType](r.Type)
 		if err != nil {
@@ -600,6 +631,10 @@ func (d *questDomain) Update(
 		quest.Rewards = append(quest.Rewards, entity.Reward{Type: rType, Data: structs.Map(reward)})
 	}
 
+	if err := setRaffle(quest, req.RaffleWinners); err != nil {
+		return nil, err
+	}
+
 	for _, c := range req.Conditions {
 		ctype, err := enum.ToEnum[entity.ConditionType](c.Type)
 		if err != nil {
@@ -687,6 +722,16 @@ func (d *questDomain) Update(
 			return nil, errorx.Unknown
 		}
 
+		// Claimed quests whose points were overridden by reviewers keep their
+		// points.
+		notOverridden := []entity.ClaimedQuest{}
+		for _, cq := range claimedQuests {
+			if !cq.OverriddenPoints.Valid {
+				notOverridden = append(notOverridden, cq)
+			}
+		}
+		claimedQuests = notOverridden
+
 		for _, cq := range claimedQuests {
 			var err error
 			if changedPoints > 0 {
@@ -856,3 +901,469 @@ func (d *questDomain) UpdateCategory(
 	xcontext.WithCommitDBTransaction(ctx)
 	return &model.UpdateQuestCategoryResponse{}, nil
 }
+
+// questBundleVersion is increased whenever the bundle format is changed in an
+// incompatible way.
+const questBundleVersion = 1
+
+func (d *questDomain) Export(
+	ctx context.Context, req *model.ExportQuestsRequest,
+) (*model.ExportQuestsResponse, error) {
+	community, err := d.communityRepo.GetByHandle(ctx, req.CommunityHandle)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found community")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get community: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	// The bundle includes secret validation data (e.g. answers of quizzes).
+	if err := d.roleVerifier.Verify(ctx, community.ID); err != nil {
+		xcontext.Logger(ctx).Debugf("Permission denied: %v", err)
+		return nil, errorx.New(errorx.PermissionDenied, "Permission denied")
+	}
+
+	categories, err := d.categoryRepo.GetList(ctx, community.ID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get categories: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	quests, err := d.questRepo.GetList(ctx, repository.SearchQuestFilter{
+		CommunityID: community.ID,
+		Statuses:    []entity.QuestStatusType{entity.QuestDraft, entity.QuestActive, entity.QuestArchived},
+		Limit:       -1,
+	})
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get quests: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	bundle := model.QuestBundle{
+		Version:    questBundleVersion,
+		Categories: []model.QuestBundleCategory{},
+		Quests:     []model.QuestBundleQuest{},
+	}
+
+	for _, category := range categories {
+		bundle.Categories = append(bundle.Categories, model.QuestBundleCategory{
+			ID:       category.ID,
+			Name:     category.Name,
+			Position: category.Position,
+		})
+	}
+
+	for _, quest := range quests {
+		if err := processValidationData(ctx, d.questFactory, true, &quest); err != nil {
+			return nil, err
+		}
+
+		bundleQuest := model.QuestBundleQuest{
+			ID:               quest.ID,
+			Type:             string(quest.Type),
+			Title:            quest.Title,
+			Description:      string(quest.Description),
+			CategoryID:       quest.CategoryID.String,
+			Recurrence:       string(quest.Recurrence),
+			ValidationData:   quest.ValidationData,
+			Points:           quest.Points,
+			Rewards:          model.ConvertRewards(quest.Rewards),
+			ConditionOp:      string(quest.ConditionOp),
+			Conditions:       model.ConvertConditions(quest.Conditions),
+			IsHighlight:      quest.IsHighlight,
+			Position:         quest.Position,
+			MaxClaims:        quest.MaxClaims,
+			MaxClaimsPerUser: quest.MaxClaimsPerUser,
+			RaffleWinners:    quest.RaffleWinners,
+		}
+
+		if quest.StartAt.Valid {
+			startAt := quest.StartAt.Time
+			bundleQuest.StartAt = &startAt
+		}
+
+		if quest.EndAt.Valid {
+			endAt := quest.EndAt.Time
+			bundleQuest.EndAt = &endAt
+		}
+
+		bundle.Quests = append(bundle.Quests, bundleQuest)
+	}
+
+	sort.SliceStable(bundle.Categories, func(i, j int) bool {
+		return bundle.Categories[i].Position < bundle.Categories[j].Position
+	})
+
+	sort.SliceStable(bundle.Quests, func(i, j int) bool {
+		if bundle.Quests[i].CategoryID != bundle.Quests[j].CategoryID {
+			return bundle.Quests[i].CategoryID < bundle.Quests[j].CategoryID
+		}
+
+		return bundle.Quests[i].Position < bundle.Quests[j].Position
+	})
+
+	return &model.ExportQuestsResponse{Bundle: bundle}, nil
+}
+
+// Import creates quests and categories of the bundle in the community. All
+// imported quests are drafts, so they can be reviewed before activating.
+func (d *questDomain) Import(
+	ctx context.Context, req *model.ImportQuestsRequest,
+) (*model.ImportQuestsResponse, error) {
+	if req.Bundle.Version != questBundleVersion {
+		return nil, errorx.New(errorx.BadRequest, "Unsupported bundle version %d", req.Bundle.Version)
+	}
+
+	if len(req.Bundle.Quests) == 0 {
+		return nil, errorx.New(errorx.BadRequest, "The bundle has no quest")
+	}
+
+	community, err := d.communityRepo.GetByHandle(ctx, req.CommunityHandle)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(errorx.NotFound, "Not found community")
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get community: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if err := d.roleVerifier.Verify(ctx, community.ID); err != nil {
+		xcontext.Logger(ctx).Debugf("Permission denied: %v", err)
+		return nil, errorx.New(errorx.PermissionDenied, "Permission denied")
+	}
+
+	// Assign new ids before creating any quest, so quest conditions can refer
+	// to quests which appear later in the bundle.
+	questIDs := map[string]string{}
+	for _, q := range req.Bundle.Quests {
+		if q.ID == "" {
+			return nil, errorx.New(errorx.BadRequest, "Quest %s has no id", q.Title)
+		}
+
+		if _, ok := questIDs[q.ID]; ok {
+			return nil, errorx.New(errorx.BadRequest, "Duplicated quest id %s", q.ID)
+		}
+
+		questIDs[q.ID] = uuid.NewString()
+	}
+
+	ctx = xcontext.WithDBTransaction(ctx)
+	defer xcontext.WithRollbackDBTransaction(ctx)
+
+	categoryIDs, err := d.importCategories(ctx, community.ID, req.Bundle.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleQuests := req.Bundle.Quests
+	sort.SliceStable(bundleQuests, func(i, j int) bool {
+		return bundleQuests[i].Position < bundleQuests[j].Position
+	})
+
+	quests := make([]*entity.Quest, len(bundleQuests))
+	for i, q := range bundleQuests {
+		quest, err := d.newBundleQuest(ctx, community.ID, q, questIDs[q.ID], categoryIDs)
+		if err != nil {
+			return nil, bundleQuestError(q.Title, err)
+		}
+
+		quests[i] = quest
+	}
+
+	// Put imported quests at the first positions of their categories, but
+	// keep their order in the bundle.
+	for i := len(quests) - 1; i >= 0; i-- {
+		err := d.questRepo.IncreasePosition(ctx, community.ID, quests[i].CategoryID.String, 0, -1)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot increase position: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if err := d.questRepo.Create(ctx, quests[i]); err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot create quest: %v", err)
+			return nil, errorx.Unknown
+		}
+	}
+
+	// Conditions are only validated after all quests are created, because a
+	// quest condition requires the dependent quest to exist.
+	for i, q := range bundleQuests {
+		if len(q.Conditions) == 0 {
+			continue
+		}
+
+		for _, c := range q.Conditions {
+			if err := remapConditionQuestIDs(c.Type, c.Data, questIDs); err != nil {
+				return nil, bundleQuestError(q.Title, err)
+			}
+
+			ctype, err := enum.ToEnum[entity.ConditionType](c.Type)
+			if err != nil {
+				return nil, bundleQuestError(q.Title,
+					errorx.New(errorx.BadRequest, "Invalid condition type %s", c.Type))
+			}
+
+			condition, err := d.questFactory.NewCondition(ctx, *quests[i], ctype, c.Data)
+			if err != nil {
+				return nil, bundleQuestError(q.Title, err)
+			}
+
+			quests[i].Conditions = append(quests[i].Conditions,
+				entity.Condition{Type: ctype, Data: structs.Map(condition)})
+		}
+
+		if err := d.questRepo.Save(ctx, quests[i]); err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot save conditions of quest: %v", err)
+			return nil, errorx.Unknown
+		}
+	}
+
+	xcontext.WithCommitDBTransaction(ctx)
+	return &model.ImportQuestsResponse{QuestIDs: questIDs}, nil
+}
+
+// importCategories returns a map from ids of categories in the bundle to ids
+// of categories in the community. Categories having the same name as existing
+// ones are merged into them.
+func (d *questDomain) importCategories(
+	ctx context.Context, communityID string, bundleCategories []model.QuestBundleCategory,
+) (map[string]string, error) {
+	sort.SliceStable(bundleCategories, func(i, j int) bool {
+		return bundleCategories[i].Position < bundleCategories[j].Position
+	})
+
+	categoryIDs := map[string]string{}
+	for _, c := range bundleCategories {
+		if c.ID == "" || c.Name == "" {
+			return nil, errorx.New(errorx.BadRequest, "Category must have both id and name")
+		}
+
+		if _, ok := categoryIDs[c.ID]; ok {
+			return nil, errorx.New(errorx.BadRequest, "Duplicated category id %s", c.ID)
+		}
+
+		category, err := d.categoryRepo.GetByName(ctx, communityID, c.Name)
+		if err == nil {
+			categoryIDs[c.ID] = category.ID
+			continue
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			xcontext.Logger(ctx).Errorf("Cannot get category by name: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		lastPosition, err := d.categoryRepo.GetLastPosition(ctx, communityID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			xcontext.Logger(ctx).Errorf("Cannot get last position: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if err == nil {
+			lastPosition += 1
+		}
+
+		category = &entity.Category{
+			Base:        entity.Base{ID: uuid.NewString()},
+			CommunityID: sql.NullString{Valid: true, String: communityID},
+			Name:        c.Name,
+			CreatedBy:   xcontext.RequestUserID(ctx),
+			Position:    lastPosition,
+		}
+
+		if err := d.categoryRepo.Create(ctx, category); err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot create category: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		categoryIDs[c.ID] = category.ID
+	}
+
+	return categoryIDs, nil
+}
+
+// newBundleQuest validates a quest of the bundle against the community and
+// converts it to a draft quest without conditions.
+func (d *questDomain) newBundleQuest(
+	ctx context.Context,
+	communityID string,
+	q model.QuestBundleQuest,
+	questID string,
+	categoryIDs map[string]string,
+) (*entity.Quest, error) {
+	quest := &entity.Quest{
+		Base:        entity.Base{ID: questID},
+		CommunityID: sql.NullString{Valid: true, String: communityID},
+		Status:      entity.QuestDraft,
+		Title:       q.Title,
+		Description: []byte(q.Description),
+		IsHighlight: q.IsHighlight,
+		Points:      q.Points,
+	}
+
+	if q.CategoryID != "" {
+		categoryID, ok := categoryIDs[q.CategoryID]
+		if !ok {
+			return nil, errorx.New(errorx.BadRequest, "Not found category %s in the bundle", q.CategoryID)
+		}
+
+		quest.CategoryID = sql.NullString{Valid: true, String: categoryID}
+	}
+
+	var err error
+	quest.Type, err = enum.ToEnum[entity.QuestType](q.Type)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Invalid quest type: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid quest type %s", q.Type)
+	}
+
+	quest.Recurrence, err = enum.ToEnum[entity.RecurrenceType](q.Recurrence)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Invalid recurrence: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid recurrence %s", q.Recurrence)
+	}
+
+	quest.ConditionOp, err = enum.ToEnum[entity.ConditionOpType](q.ConditionOp)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Invalid condition op: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid condition op %s", q.ConditionOp)
+	}
+
+	if err := setClaimLimit(quest, q.MaxClaims, q.MaxClaimsPerUser); err != nil {
+		return nil, err
+	}
+
+	// The schedule of the original quest is not imported, it is usually
+	// outdated. Imported quests are drafts, the new community should set the
+	// schedule before activating them.
+
+	// Discord roles, tokens, etc. of rewards are validated against the new
+	// community.
+	for _, r := range q.Rewards {
+		rType, err := enum.ToEnum[entity.RewardType](r.Type)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Invalid reward type: %v", err)
+			return nil, errorx.New(errorx.BadRequest, "Invalid reward type %s", r.Type)
+		}
+
+		reward, err := d.questFactory.NewReward(ctx, communityID, rType, r.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		quest.Rewards = append(quest.Rewards, entity.Reward{Type: rType, Data: structs.Map(reward)})
+	}
+
+	if err := setRaffle(quest, q.RaffleWinners); err != nil {
+		return nil, err
+	}
+
+	processor, err := d.questFactory.NewProcessor(ctx, *quest, q.ValidationData)
+	if err != nil {
+		return nil, err
+	}
+	quest.ValidationData = structs.Map(processor)
+
+	return quest, nil
+}
+
+// remapConditionQuestIDs replaces ids of quests in the bundle referred by the
+// quest conditions (including ones nested in condition groups) with the new
+// ids.
+func remapConditionQuestIDs(conditionType string, data map[string]any, questIDs map[string]string) error {
+	switch conditionType {
+	case string(entity.QuestCondition):
+		oldID, _ := data["quest_id"].(string)
+		newID, ok := questIDs[oldID]
+		if !ok {
+			return errorx.New(errorx.BadRequest, "Quest condition refers to quest %s which is not in the bundle", oldID)
+		}
+
+		data["quest_id"] = newID
+
+	case string(entity.GroupCondition):
+		nodes, _ := data["conditions"].([]any)
+		for _, node := range nodes {
+			nodeMap, ok := node.(map[string]any)
+			if !ok {
+				return errorx.New(errorx.BadRequest, "Invalid condition group")
+			}
+
+			nodeType, _ := nodeMap["type"].(string)
+			nodeData, _ := nodeMap["data"].(map[string]any)
+			if err := remapConditionQuestIDs(nodeType, nodeData, questIDs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// bundleQuestError adds the quest title to the client-facing message, so users
+// can know which quest of the bundle is invalid.
+func bundleQuestError(title string, err error) error {
+	var errx errorx.Error
+	if errors.As(err, &errx) {
+		return errorx.New(errx.Code, "Quest %s: %s", title, errx.Message)
+	}
+
+	return err
+}
+
+func setClaimLimit(quest *entity.Quest, maxClaims, maxClaimsPerUser int) error {
+	if maxClaims < 0 || maxClaimsPerUser < 0 {
+		return errorx.New(errorx.BadRequest, "Limit of claims must not be negative")
+	}
+
+	if maxClaimsPerUser > 0 && quest.Recurrence == entity.Once {
+		return errorx.New(errorx.BadRequest, "Only recurring quests can limit claims per user")
+	}
+
+	quest.MaxClaims = maxClaims
+	quest.MaxClaimsPerUser = maxClaimsPerUser
+	return nil
+}
+
+func setSchedule(quest *entity.Quest, startAt, endAt time.Time) error {
+	if !startAt.IsZero() && !endAt.IsZero() && !endAt.After(startAt) {
+		return errorx.New(errorx.BadRequest, "End time must be after start time")
+	}
+
+	quest.StartAt = sql.NullTime{Valid: !startAt.IsZero(), Time: startAt}
+	quest.EndAt = sql.NullTime{Valid: !endAt.IsZero(), Time: endAt}
+	return nil
+}
+
+func setRaffle(quest *entity.Quest, raffleWinners int) error {
+	if raffleWinners < 0 {
+		return errorx.New(errorx.BadRequest, "Number of raffle winners must not be negative")
+	}
+
+	if raffleWinners > 0 {
+		// A draft may not have the end time yet (e.g. imported quests), but it
+		// must be set before the quest is activated, manually or on schedule.
+		isUnscheduledDraft := quest.Status == entity.QuestDraft && !quest.StartAt.Valid
+		if !quest.EndAt.Valid && !isUnscheduledDraft {
+			return errorx.New(errorx.BadRequest, "Raffle quest requires an end time")
+		}
+
+		hasPaidReward := false
+		for _, r := range quest.Rewards {
+			if r.Type == entity.CoinReward || r.Type == entity.NFTReward {
+				hasPaidReward = true
+				break
+			}
+		}
+
+		if !hasPaidReward {
+			return errorx.New(errorx.BadRequest, "Raffle quest requires a coin or nft reward")
+		}
+	}
+
+	quest.RaffleWinners = raffleWinners
+	return nil
+}
@@ -2,13 +2,27 @@ package domain
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/questx-lab/backend/internal/common"
+	"github.com/questx-lab/backend/internal/domain/badge"
+	"github.com/questx-lab/backend/internal/domain/blockchain/types"
+	"github.com/questx-lab/backend/internal/domain/questclaim"
 	"github.com/questx-lab/backend/internal/entity"
 	"github.com/questx-lab/backend/internal/model"
 	"github.com/questx-lab/backend/internal/repository"
+	"github.com/questx-lab/backend/pkg/api/discord"
+	"github.com/questx-lab/backend/pkg/api/twitter"
 	"github.com/questx-lab/backend/pkg/errorx"
 	"github.com/questx-lab/backend/pkg/reflectutil"
 	"github.com/questx-lab/backend/pkg/testutil"
@@ -180,6 +194,183 @@ func Test_questDomain_Create_Successfully(t *testing.T) {
 	require.Equal(t, entity.Or, result.ConditionOp)
 }
 
+func Test_questDomain_Create_GroupCondition(t *testing.T) {
+	ctx := testutil.MockContextWithUserID(t, testutil.Community1.CreatedBy)
+	testutil.CreateFixtureDb(ctx)
+	questFactory := testutil.NewQuestFactory(ctx)
+	questDomain := NewQuestDomain(
+		repository.NewQuestRepository(&testutil.MockSearchCaller{}),
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewClaimedQuestRepository(),
+		repository.NewFollowerRepository(),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		questFactory,
+	)
+	ctx = xcontext.WithHTTPRequest(ctx, httptest.NewRequest("GET", "/createQuest", nil))
+
+	dateCondition := func(op, date string) map[string]any {
+		return map[string]any{"type": "date", "data": map[string]any{"op": op, "date": date}}
+	}
+
+	createQuestReq := &model.CreateQuestRequest{
+		CommunityHandle: testutil.Community1.Handle,
+		Title:           "new-quest",
+		Type:            "text",
+		Status:          "active",
+		Recurrence:      "once",
+		ConditionOp:     "and",
+		ValidationData:  map[string]any{},
+		Conditions: []model.Condition{
+			{
+				Type: "group",
+				Data: map[string]any{
+					"op": "or",
+					"conditions": []any{
+						map[string]any{
+							"type": "group",
+							"data": map[string]any{
+								"op": "and",
+								"conditions": []any{
+									dateCondition("after", "Jan 01 2000"),
+									dateCondition("before", "Jan 01 2001"),
+								},
+							},
+						},
+						dateCondition("after", "Jan 01 2001"),
+					},
+				},
+			},
+		},
+	}
+
+	questResp, err := questDomain.Create(ctx, createQuestReq)
+	require.NoError(t, err)
+
+	quest, err := repository.NewQuestRepository(&testutil.MockSearchCaller{}).GetByID(ctx, questResp.ID)
+	require.NoError(t, err)
+	require.Len(t, quest.Conditions, 1)
+
+	condition, err := questFactory.LoadCondition(ctx, *quest, quest.Conditions[0].Type, quest.Conditions[0].Data)
+	require.NoError(t, err)
+	require.Equal(t,
+		"(You can only claim this quest after Jan 01 2000 AND You can only claim this quest before Jan 01 2001) "+
+			"OR You can only claim this quest after Jan 01 2001",
+		condition.Statement(),
+	)
+
+	ok, err := condition.Check(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A nested condition is validated as well as a top-level one.
+	createQuestReq.Conditions[0].Data["conditions"] = []any{dateCondition("after", "invalid date")}
+	_, err = questDomain.Create(ctx, createQuestReq)
+	require.Equal(t, errorx.New(errorx.BadRequest, "Invalid date format"), err)
+}
+
+func Test_questFactory_FollowerCondition(t *testing.T) {
+	ctx := testutil.MockContextWithUserID(t, testutil.User1.ID)
+	testutil.CreateFixtureDb(ctx)
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	tests := []struct {
+		conditionType entity.ConditionType
+		value         uint64
+		want          bool
+	}{
+		{conditionType: entity.PointsCondition, value: 1000, want: true},
+		{conditionType: entity.PointsCondition, value: 1001, want: false},
+		{conditionType: entity.QuestCountCondition, value: 10, want: true},
+		{conditionType: entity.QuestCountCondition, value: 11, want: false},
+		{conditionType: entity.StreakCondition, value: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		condition, err := questFactory.NewCondition(
+			ctx, *testutil.Quest1, tt.conditionType, map[string]any{"value": tt.value})
+		require.NoError(t, err)
+
+		ok, err := condition.Check(ctx)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, ok, "%s %d", tt.conditionType, tt.value)
+	}
+
+	_, err := questFactory.NewCondition(ctx, *testutil.Quest1, entity.ChatLevelCondition, map[string]any{})
+	require.Equal(t, errorx.New(errorx.BadRequest, "Value of condition must be positive"), err)
+}
+
+func Test_questFactory_RoleCondition(t *testing.T) {
+	ctx := testutil.MockContextWithUserID(t, testutil.User1.ID)
+	testutil.CreateFixtureDb(ctx)
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	tests := []struct {
+		roleID string
+		want   bool
+	}{
+		{roleID: "owner", want: true},
+		{roleID: "community1_manage_role", want: false},
+	}
+
+	for _, tt := range tests {
+		condition, err := questFactory.NewCondition(
+			ctx, *testutil.Quest1, entity.RoleCondition, map[string]any{"role_id": tt.roleID})
+		require.NoError(t, err)
+
+		ok, err := condition.Check(ctx)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, ok, tt.roleID)
+	}
+
+	_, err := questFactory.NewCondition(
+		ctx, *testutil.Quest1, entity.BadgeCondition, map[string]any{"name": "unknown", "level": 1})
+	require.Equal(t, errorx.New(errorx.NotFound, "Not found badge unknown level 1"), err)
+}
+
+func Test_questFactory_BadgeCondition(t *testing.T) {
+	ctx := testutil.MockContextWithUserID(t, testutil.User1.ID)
+	testutil.CreateFixtureDb(ctx)
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	err := repository.NewBadgeDetailRepository().Create(ctx, &entity.BadgeDetail{
+		UserID:      testutil.User1.ID,
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+		BadgeID:     testutil.BadgeSharpScout2.ID,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		level int
+		want  bool
+	}{
+		{level: 1, want: true},
+		{level: 2, want: true},
+		{level: 3, want: false},
+	}
+
+	for _, tt := range tests {
+		condition, err := questFactory.NewCondition(ctx, *testutil.Quest1, entity.BadgeCondition,
+			map[string]any{"name": testutil.BadgeSharpScout2.Name, "level": tt.level})
+		require.NoError(t, err)
+
+		ok, err := condition.Check(ctx)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, ok, tt.level)
+	}
+
+	// A user without the badge does not pass.
+	condition, err := questFactory.NewCondition(ctx, *testutil.Quest1, entity.BadgeCondition,
+		map[string]any{"name": testutil.BadgeSharpScout2.Name, "level": 1})
+	require.NoError(t, err)
+
+	ok, err := condition.Check(xcontext.WithRequestUserID(ctx, testutil.User2.ID))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
 func Test_questDomain_Get(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -704,3 +895,951 @@ func Test_questDomain_Update_Point(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, testutil.Follower1.Points-20, follower.Points)
 }
+
+func Test_questDomain_ExportImport(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	questDomain := NewQuestDomain(
+		questRepo,
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewClaimedQuestRepository(),
+		repository.NewFollowerRepository(),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		testutil.NewQuestFactory(ctx),
+	)
+
+	exportCtx := xcontext.WithRequestUserID(ctx, testutil.Community1.CreatedBy)
+	exportCtx = xcontext.WithHTTPRequest(exportCtx, httptest.NewRequest("GET", "/exportQuests", nil))
+	exportResp, err := questDomain.Export(exportCtx, &model.ExportQuestsRequest{
+		CommunityHandle: testutil.Community1.Handle,
+	})
+	require.NoError(t, err)
+	require.Equal(t, questBundleVersion, exportResp.Bundle.Version)
+	require.Len(t, exportResp.Bundle.Categories, 3)
+	require.Len(t, exportResp.Bundle.Quests, 4)
+
+	importCtx := xcontext.WithRequestUserID(ctx, testutil.Community2.CreatedBy)
+	importCtx = xcontext.WithHTTPRequest(importCtx, httptest.NewRequest("POST", "/importQuests", nil))
+
+	importResp, err := questDomain.Import(importCtx, &model.ImportQuestsRequest{
+		CommunityHandle: testutil.Community2.Handle,
+		Bundle:          exportResp.Bundle,
+	})
+	require.NoError(t, err)
+	require.Len(t, importResp.QuestIDs, 4)
+
+	quest1, err := questRepo.GetByID(ctx, importResp.QuestIDs[testutil.Quest1.ID])
+	require.NoError(t, err)
+	require.Equal(t, testutil.Community2.ID, quest1.CommunityID.String)
+	require.Equal(t, testutil.Quest1.Title, quest1.Title)
+	require.Equal(t, entity.QuestDraft, quest1.Status)
+	require.NotEqual(t, testutil.Quest1.CategoryID.String, quest1.CategoryID.String)
+
+	category, err := repository.NewCategoryRepository().GetByID(ctx, quest1.CategoryID.String)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Community2.ID, category.CommunityID.String)
+	require.Equal(t, testutil.Category1.Name, category.Name)
+
+	// The quest condition refers to the imported quest instead of the original.
+	quest2, err := questRepo.GetByID(ctx, importResp.QuestIDs[testutil.Quest2.ID])
+	require.NoError(t, err)
+	require.Equal(t, entity.QuestDraft, quest2.Status)
+	require.Len(t, quest2.Conditions, 1)
+	require.Equal(t, quest1.ID, quest2.Conditions[0].Data["quest_id"])
+
+	// A quest condition must refer to a quest in the bundle.
+	bundle := model.QuestBundle{
+		Version: questBundleVersion,
+		Quests: []model.QuestBundleQuest{{
+			ID:             "quest",
+			Type:           string(entity.QuestVisitLink),
+			Title:          "Visit",
+			Recurrence:     string(entity.Once),
+			ConditionOp:    string(entity.And),
+			ValidationData: map[string]any{"link": "https://example.com"},
+			Conditions: []model.Condition{{
+				Type: string(entity.QuestCondition),
+				Data: map[string]any{"op": "is_completed", "quest_id": testutil.Quest1.ID},
+			}},
+		}},
+	}
+	_, err = questDomain.Import(importCtx, &model.ImportQuestsRequest{
+		CommunityHandle: testutil.Community2.Handle,
+		Bundle:          bundle,
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.BadRequest,
+		"Quest Visit: Quest condition refers to quest %s which is not in the bundle", testutil.Quest1.ID))
+
+	bundle.Version = questBundleVersion + 1
+	_, err = questDomain.Import(importCtx, &model.ImportQuestsRequest{
+		CommunityHandle: testutil.Community2.Handle,
+		Bundle:          bundle,
+	})
+	require.ErrorIs(t, err, errorx.New(errorx.BadRequest, "Unsupported bundle version %d", questBundleVersion+1))
+}
+
+func Test_questDomain_Import_Rewards(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questRepo := repository.NewQuestRepository(&testutil.MockSearchCaller{})
+	badgeRepo := repository.NewBadgeRepository()
+
+	blockchainRepo := repository.NewBlockChainRepository()
+	require.NoError(t, blockchainRepo.Upsert(ctx, &entity.Blockchain{Name: "eth", ID: 1}))
+	require.NoError(t, blockchainRepo.CreateToken(ctx, &entity.BlockchainToken{
+		Base:     entity.Base{ID: "usdt"},
+		Symbol:   "USDT",
+		Address:  "0xusdt",
+		Chain:    "eth",
+		Decimals: 6,
+	}))
+
+	// Discord roles are resolved again in the server of the new community.
+	questFactory := testutil.NewQuestFactoryWithMocks(ctx, testutil.QuestFactoryMocks{
+		DiscordEndpoint: &testutil.MockDiscordEndpoint{
+			HasAddedBotFunc: func(ctx context.Context, guildID string) (bool, error) {
+				return true, nil
+			},
+			GetRolesFunc: func(ctx context.Context, guildID string) ([]discord.Role, error) {
+				return []discord.Role{{ID: "vip_of_" + guildID, Name: "vip"}}, nil
+			},
+		},
+	})
+
+	questDomain := NewQuestDomain(
+		questRepo,
+		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
+		repository.NewCategoryRepository(),
+		repository.NewUserRepository(testutil.RedisClient(ctx)),
+		repository.NewClaimedQuestRepository(),
+		repository.NewFollowerRepository(),
+		&testutil.MockLeaderboard{},
+		testutil.NewCommunityRoleVerifier(ctx),
+		questFactory,
+	)
+
+	// The original community has defined its own early bird badge.
+	require.NoError(t, badgeRepo.Create(ctx, &entity.Badge{
+		Base:        entity.Base{ID: "community1 early bird"},
+		Name:        "early bird",
+		Level:       1,
+		CommunityID: sql.NullString{Valid: true, String: testutil.Community1.ID},
+	}))
+
+	pastTime := time.Now().Add(-time.Hour)
+	rewardQuest := model.QuestBundleQuest{
+		ID:             "reward quest",
+		Type:           string(entity.QuestVisitLink),
+		Title:          "Rewards",
+		Recurrence:     string(entity.Once),
+		ConditionOp:    string(entity.And),
+		ValidationData: map[string]any{"link": "https://example.com"},
+		Rewards: []model.Reward{
+			{Type: string(entity.BadgeReward), Data: map[string]any{"name": "early bird", "level": 1}},
+			{Type: string(entity.DiscordRoleReward), Data: map[string]any{"role": "vip", "role_id": "vip_of_1234"}},
+			{Type: string(entity.CoinReward), Data: map[string]any{"chain": "eth", "token_address": "0xusdt", "amount": 10}},
+		},
+		StartAt:       &pastTime,
+		EndAt:         &pastTime,
+		RaffleWinners: 1,
+	}
+
+	// The quest condition nested in condition groups is remapped too.
+	groupQuest := model.QuestBundleQuest{
+		ID:             "group quest",
+		Type:           string(entity.QuestVisitLink),
+		Title:          "Group",
+		Recurrence:     string(entity.Once),
+		ConditionOp:    string(entity.And),
+		ValidationData: map[string]any{"link": "https://example.com"},
+		Conditions: []model.Condition{{
+			Type: string(entity.GroupCondition),
+			Data: map[string]any{
+				"op": string(entity.Or),
+				"conditions": []any{map[string]any{
+					"type": string(entity.GroupCondition),
+					"data": map[string]any{
+						"op": string(entity.And),
+						"conditions": []any{map[string]any{
+							"type": string(entity.QuestCondition),
+							"data": map[string]any{"op": "is_completed", "quest_id": rewardQuest.ID},
+						}},
+					},
+				}},
+			},
+		}},
+	}
+
+	importCtx := xcontext.WithRequestUserID(ctx, testutil.Community2.CreatedBy)
+	importCtx = xcontext.WithHTTPRequest(importCtx, httptest.NewRequest("POST", "/importQuests", nil))
+	importResp, err := questDomain.Import(importCtx, &model.ImportQuestsRequest{
+		CommunityHandle: testutil.Community2.Handle,
+		Bundle: model.QuestBundle{
+			Version: questBundleVersion,
+			Quests:  []model.QuestBundleQuest{rewardQuest, groupQuest},
+		},
+	})
+	require.NoError(t, err)
+
+	// The outdated schedule is not imported, the raffle is kept in the draft.
+	importedRewardQuest, err := questRepo.GetByID(ctx, importResp.QuestIDs[rewardQuest.ID])
+	require.NoError(t, err)
+	require.Equal(t, entity.QuestDraft, importedRewardQuest.Status)
+	require.False(t, importedRewardQuest.StartAt.Valid)
+	require.False(t, importedRewardQuest.EndAt.Valid)
+	require.Equal(t, 1, importedRewardQuest.RaffleWinners)
+	require.Len(t, importedRewardQuest.Rewards, 3)
+	require.Equal(t, testutil.Community2.Discord, importedRewardQuest.Rewards[1].Data["guild_id"])
+	require.Equal(t, "vip_of_"+testutil.Community2.Discord, importedRewardQuest.Rewards[1].Data["role_id"])
+	require.Equal(t, "usdt", importedRewardQuest.Rewards[2].Data["token_id"])
+
+	importedGroupQuest, err := questRepo.GetByID(ctx, importResp.QuestIDs[groupQuest.ID])
+	require.NoError(t, err)
+	require.Len(t, importedGroupQuest.Conditions, 1)
+	outerNodes := importedGroupQuest.Conditions[0].Data["conditions"].([]any)
+	innerData := outerNodes[0].(map[string]any)["data"].(map[string]any)
+	innerNodes := innerData["conditions"].([]any)
+	questConditionData := innerNodes[0].(map[string]any)["data"].(map[string]any)
+	require.Equal(t, importedRewardQuest.ID, questConditionData["quest_id"])
+
+	// The new community gets its own early bird badge.
+	badgeReward := importedRewardQuest.Rewards[0]
+	reward, err := questFactory.LoadReward(ctx, testutil.Community2.ID, badgeReward.Type, badgeReward.Data)
+	require.NoError(t, err)
+	reward.WithClaimedQuest(&entity.ClaimedQuest{UserID: testutil.User2.ID})
+	require.NoError(t, reward.Give(ctx))
+
+	earlyBird, err := badgeRepo.Get(ctx, testutil.Community2.ID, "early bird", 1)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Community2.ID, earlyBird.CommunityID.String)
+
+	// Rewards are validated in the new community.
+	tests := []struct {
+		name   string
+		reward model.Reward
+		err    error
+	}{
+		{
+			name:   "unknown discord role",
+			reward: model.Reward{Type: string(entity.DiscordRoleReward), Data: map[string]any{"role": "admin"}},
+			err:    errorx.New(errorx.Unavailable, "Quest Rewards: Invalid role admin"),
+		},
+		{
+			name: "unsupported token",
+			reward: model.Reward{Type: string(entity.CoinReward),
+				Data: map[string]any{"chain": "eth", "token_address": "0xunknown", "amount": 10}},
+			err: errorx.New(errorx.NotFound, "Quest Rewards: Got an unsupported token 0xunknown on chain eth"),
+		},
+		{
+			name: "reserved badge",
+			reward: model.Reward{Type: string(entity.BadgeReward),
+				Data: map[string]any{"name": badge.SharpScoutBadgeName}},
+			err: errorx.New(errorx.Unavailable, "Quest Rewards: Badge name %s is reserved", badge.SharpScoutBadgeName),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalidQuest := rewardQuest
+			invalidQuest.RaffleWinners = 0
+			invalidQuest.Rewards = []model.Reward{tt.reward}
+
+			_, err := questDomain.Import(importCtx, &model.ImportQuestsRequest{
+				CommunityHandle: testutil.Community2.Handle,
+				Bundle: model.QuestBundle{
+					Version: questBundleVersion,
+					Quests:  []model.QuestBundleQuest{invalidQuest},
+				},
+			})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_questFactory_HoldERC20(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+
+	blockchainRepo := repository.NewBlockChainRepository()
+	require.NoError(t, blockchainRepo.Upsert(ctx, &entity.Blockchain{Name: "eth", ID: 1}))
+	require.NoError(t, blockchainRepo.CreateToken(ctx, &entity.BlockchainToken{
+		Base:     entity.Base{ID: "usdt"},
+		Symbol:   "USDT",
+		Address:  "0xusdt",
+		Chain:    "eth",
+		Decimals: 6,
+	}))
+	require.NoError(t, blockchainRepo.Upsert(ctx, &entity.Blockchain{Name: "polygon", ID: 137}))
+	require.NoError(t, blockchainRepo.CreateToken(ctx, &entity.BlockchainToken{
+		Base:     entity.Base{ID: "weth"},
+		Symbol:   "WETH",
+		Address:  "0xweth",
+		Chain:    "polygon",
+		Decimals: 18,
+	}))
+
+	// The user holds exactly 1.5 USDT, 0.1 WETH, or 1.1 WETH depending on the
+	// test case.
+	var balance *big.Int
+	questFactory := testutil.NewQuestFactoryWithMocks(ctx, testutil.QuestFactoryMocks{
+		BlockchainCaller: &testutil.MockBlockchainCaller{
+			ERC20BalanceOfFunc: func(ctx context.Context, chain, tokenAddress, accountAddress string) (*big.Int, error) {
+				return balance, nil
+			},
+		},
+	})
+
+	quest := entity.Quest{Type: entity.QuestHoldERC20, CommunityID: testutil.Quest1.CommunityID}
+	_, err := questFactory.NewProcessor(ctx, quest,
+		map[string]any{"chain": "eth", "token_address": "0xunknown", "amount": 1})
+	require.Equal(t, errorx.New(errorx.NotFound, "Got an unsupported token 0xunknown on chain eth"), err)
+
+	_, err = questFactory.NewProcessor(ctx, quest,
+		map[string]any{"chain": "eth", "token_address": "0xusdt", "amount": "0.0000001"})
+	require.Equal(t, errorx.New(errorx.BadRequest, "Amount must be a decimal with at most 6 fractional digits"), err)
+
+	_, err = questFactory.NewProcessor(ctx, quest,
+		map[string]any{"chain": "eth", "token_address": "0xusdt", "amount": "0"})
+	require.Equal(t, errorx.New(errorx.BadRequest, "Amount must be a positive"), err)
+
+	oneTenthWETH, _ := new(big.Int).SetString("100000000000000000", 10)
+	oneAndOneTenthWETH, _ := new(big.Int).SetString("1100000000000000000", 10)
+
+	tests := []struct {
+		name    string
+		userID  string
+		chain   string
+		token   string
+		amount  any
+		balance *big.Int
+		want    questclaim.ActionForClaim
+		err     error
+	}{
+		{
+			name:    "enough balance",
+			userID:  testutil.User1.ID,
+			chain:   "eth",
+			token:   "0xusdt",
+			amount:  1.5,
+			balance: big.NewInt(1_500_000),
+			want:    questclaim.Accepted,
+		},
+		{
+			name:    "not enough balance",
+			userID:  testutil.User1.ID,
+			chain:   "eth",
+			token:   "0xusdt",
+			amount:  2,
+			balance: big.NewInt(1_500_000),
+			want:    questclaim.Rejected,
+		},
+		{
+			name:    "exact balance with 18 decimals",
+			userID:  testutil.User1.ID,
+			chain:   "polygon",
+			token:   "0xweth",
+			amount:  "0.1",
+			balance: oneTenthWETH,
+			want:    questclaim.Accepted,
+		},
+		{
+			name:    "exact balance stored as a number with 18 decimals",
+			userID:  testutil.User1.ID,
+			chain:   "polygon",
+			token:   "0xweth",
+			amount:  1.1,
+			balance: oneAndOneTenthWETH,
+			want:    questclaim.Accepted,
+		},
+		{
+			name:    "one wei less than required",
+			userID:  testutil.User1.ID,
+			chain:   "polygon",
+			token:   "0xweth",
+			amount:  "1.1",
+			balance: new(big.Int).Sub(oneAndOneTenthWETH, big.NewInt(1)),
+			want:    questclaim.Rejected,
+		},
+		{
+			name:   "no wallet",
+			userID: testutil.User3.ID,
+			chain:  "eth",
+			token:  "0xusdt",
+			amount: 1,
+			err:    errorx.New(errorx.Unavailable, "User has not connected to wallet"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balance = tt.balance
+			processor, err := questFactory.NewProcessor(ctx, quest,
+				map[string]any{"chain": tt.chain, "token_address": tt.token, "amount": tt.amount})
+			require.NoError(t, err)
+
+			action, err := processor.GetActionForClaim(xcontext.WithRequestUserID(ctx, tt.userID), "")
+			if tt.err != nil {
+				require.Equal(t, tt.err, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, action.Is(tt.want), action.Name())
+		})
+	}
+}
+
+func Test_questFactory_HoldNFT(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+
+	require.NoError(t, repository.NewBlockChainRepository().Upsert(ctx, &entity.Blockchain{Name: "eth", ID: 1}))
+	require.NoError(t, repository.NewNftRepository().Create(ctx, &entity.NonFungibleToken{
+		SnowFlakeBase: entity.SnowFlakeBase{ID: 1},
+		CommunityID:   testutil.Community1.ID,
+		CreatedBy:     testutil.User1.ID,
+		Chain:         "eth",
+		Name:          "XQuest",
+	}))
+
+	// User1 claimed fewer tokens than any tested amount, so the balance is
+	// always read on chain. User3 has no wallet, but owns the claimed tokens.
+	require.NoError(t, repository.NewNftRepository().UpsertClaimedToken(ctx, &entity.ClaimedNonFungibleToken{
+		UserID:             testutil.User1.ID,
+		NonFungibleTokenID: 1,
+		Amount:             1,
+	}))
+	require.NoError(t, repository.NewNftRepository().UpsertClaimedToken(ctx, &entity.ClaimedNonFungibleToken{
+		UserID:             testutil.User3.ID,
+		NonFungibleTokenID: 1,
+		Amount:             2,
+	}))
+
+	hugeBalance, _ := new(big.Int).SetString("100000000000000000000", 10)
+	balances := map[string]*big.Int{
+		testutil.User1.WalletAddress.String: big.NewInt(2),
+		testutil.User2.WalletAddress.String: hugeBalance,
+	}
+
+	questFactory := testutil.NewQuestFactoryWithMocks(ctx, testutil.QuestFactoryMocks{
+		BlockchainCaller: &testutil.MockBlockchainCaller{
+			ERC1155BalanceOfFunc: func(ctx context.Context, chain, address string, tokenID int64) (*big.Int, error) {
+				if balance, ok := balances[address]; ok {
+					return balance, nil
+				}
+
+				return big.NewInt(0), nil
+			},
+		},
+	})
+
+	quest := entity.Quest{Type: entity.QuestHoldNFT, CommunityID: testutil.Quest1.CommunityID}
+	_, err := questFactory.NewProcessor(ctx, quest, map[string]any{"token_id": 2, "amount": 1})
+	require.Equal(t, errorx.New(errorx.NotFound, "Not found NFT"), err)
+
+	tests := []struct {
+		name   string
+		userID string
+		amount int
+		want   bool
+	}{
+		{name: "enough balance", userID: testutil.User1.ID, amount: 2, want: true},
+		{name: "not enough balance", userID: testutil.User1.ID, amount: 3, want: false},
+		{name: "balance overflows int64", userID: testutil.User2.ID, amount: 1000, want: true},
+		{name: "claimed tokens cover amount", userID: testutil.User3.ID, amount: 2, want: true},
+		{name: "no wallet and not enough claimed tokens", userID: testutil.User3.ID, amount: 3, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]any{"token_id": 1, "amount": tt.amount}
+			userCtx := xcontext.WithRequestUserID(ctx, tt.userID)
+
+			processor, err := questFactory.NewProcessor(ctx, quest, data)
+			require.NoError(t, err)
+
+			action, err := processor.GetActionForClaim(userCtx, "")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, action.Is(questclaim.Accepted), action.Name())
+
+			condition, err := questFactory.NewCondition(ctx, quest, entity.NFTCondition, data)
+			require.NoError(t, err)
+
+			ok, err := condition.Check(userCtx)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func Test_questFactory_ContractInteraction(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+
+	require.NoError(t, repository.NewBlockChainRepository().Upsert(ctx, &entity.Blockchain{Name: "eth", ID: 1}))
+
+	const (
+		contract      = "0x1111111111111111111111111111111111111111"
+		otherContract = "0x2222222222222222222222222222222222222222"
+		transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+		usedTxHash    = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		newTxHash     = "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+
+	require.NoError(t, repository.NewClaimedQuestRepository().Create(ctx, &entity.ClaimedQuest{
+		Base:           entity.Base{ID: "used-tx-claimed-quest"},
+		QuestID:        testutil.Quest1.ID,
+		UserID:         testutil.User2.ID,
+		Status:         entity.AutoAccepted,
+		SubmissionData: usedTxHash,
+	}))
+
+	questFactory := testutil.NewQuestFactoryWithMocks(ctx, testutil.QuestFactoryMocks{
+		BlockchainCaller: &testutil.MockBlockchainCaller{
+			TransactionReceiptFunc: func(ctx context.Context, chain, txHash string) (types.TransactionReceipt, error) {
+				return types.TransactionReceipt{
+					TxHash:  txHash,
+					Success: true,
+					From:    "0x0000000000000000000000000000000000000000",
+					To:      contract,
+					Logs: []types.ReceiptLog{
+						// The event is emitted by another contract.
+						{Address: otherContract, Topics: []string{transferTopic}},
+					},
+				}, nil
+			},
+		},
+	})
+
+	quest := *testutil.Quest1
+	quest.Type = entity.QuestContractInteraction
+
+	tests := []struct {
+		name           string
+		event          string
+		submissionData string
+		want           questclaim.ActionForClaim
+		wantMessage    string
+	}{
+		{
+			name:           "accepted",
+			submissionData: newTxHash,
+			want:           questclaim.Accepted,
+		},
+		{
+			name:           "used transaction in another case",
+			submissionData: "0x" + strings.ToUpper(usedTxHash[2:]),
+			want:           questclaim.Rejected,
+			wantMessage:    "This transaction has been used to claim the quest",
+		},
+		{
+			name:           "event emitted by another contract",
+			event:          "Transfer(address,address,uint256)",
+			submissionData: newTxHash,
+			want:           questclaim.Rejected,
+			wantMessage:    "The transaction doesn't emit the event Transfer(address,address,uint256)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := questFactory.NewProcessor(ctx, quest, map[string]any{
+				"chain":            "eth",
+				"contract_address": contract,
+				"event":            tt.event,
+			})
+			require.NoError(t, err)
+
+			action, err := processor.GetActionForClaim(
+				xcontext.WithRequestUserID(ctx, testutil.User1.ID), tt.submissionData)
+			require.NoError(t, err)
+			require.True(t, action.Is(tt.want), action.Name())
+			require.Equal(t, tt.wantMessage, action.Message())
+		})
+	}
+}
+
+func Test_questFactory_SignMessage(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	quest := *testutil.Quest1
+	quest.Type = entity.QuestSignMessage
+
+	_, err := questFactory.NewProcessor(ctx, quest, map[string]any{"message": "I love {{.quest_id}}"})
+	require.Equal(t, errorx.New(errorx.BadRequest, "The message template must include {{.user_id}}"), err)
+
+	processor, err := questFactory.NewProcessor(ctx, quest,
+		map[string]any{"message": "I am {{.user_id}} and I love {{.quest_id}}"})
+	require.NoError(t, err)
+
+	privateKey, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	address := ethcrypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	message := fmt.Sprintf("I am %s and I love %s", testutil.User1.ID, quest.ID)
+	signature, err := ethcrypto.Sign(accounts.TextHash([]byte(message)), privateKey)
+	require.NoError(t, err)
+
+	// The signer claims the quest.
+	action, err := processor.GetActionForClaim(questclaim.WithClaimWalletAddress(
+		xcontext.WithRequestUserID(ctx, testutil.User1.ID), address), hexutil.Encode(signature))
+	require.NoError(t, err)
+	require.True(t, action.Is(questclaim.Accepted), action.Message())
+
+	// Another user cannot reuse the signature.
+	action, err = processor.GetActionForClaim(questclaim.WithClaimWalletAddress(
+		xcontext.WithRequestUserID(ctx, testutil.User2.ID), address), hexutil.Encode(signature))
+	require.NoError(t, err)
+	require.True(t, action.Is(questclaim.Rejected), action.Name())
+}
+
+func Test_questFactory_DiscordMessage(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+
+	require.NoError(t, repository.NewOAuth2Repository().Create(ctx, &entity.OAuth2{
+		UserID:        testutil.User1.ID,
+		Service:       "discord",
+		ServiceUserID: "discord_user1",
+	}))
+
+	// Every page is full and contains only one message of user1.
+	pages := 0
+	discordEndpoint := &testutil.MockDiscordEndpoint{
+		HasAddedBotFunc: func(ctx context.Context, guildID string) (bool, error) {
+			return true, nil
+		},
+		GetChannelFunc: func(ctx context.Context, channelID string) (discord.Channel, error) {
+			guilds := map[string]string{"general": "1234", "another": "5678"}
+			return discord.Channel{ID: channelID, GuildID: guilds[channelID]}, nil
+		},
+		GetChannelMessagesFunc: func(ctx context.Context, channelID, afterID string, limit int) ([]discord.Message, error) {
+			pages++
+			messages := make([]discord.Message, limit)
+			for i := range messages {
+				messages[i] = discord.Message{ID: fmt.Sprint(pages*limit + i), Author: discord.User{ID: "other"}}
+			}
+			messages[0].Author.ID = "user1"
+			return messages, nil
+		},
+	}
+	questFactory := testutil.NewQuestFactoryWithMocks(ctx, testutil.QuestFactoryMocks{DiscordEndpoint: discordEndpoint})
+
+	quest := *testutil.Quest1
+	quest.Type = entity.QuestDiscordMessage
+
+	_, err := questFactory.NewProcessor(ctx, quest, map[string]any{"channel_id": "another", "number": 1})
+	require.Equal(t, errorx.New(errorx.BadRequest, "The channel doesn't belong to discord server of community"), err)
+
+	userCtx := xcontext.WithRequestUserID(ctx, testutil.User1.ID)
+	processor, err := questFactory.NewProcessor(ctx, quest, map[string]any{"channel_id": "general", "number": 20})
+	require.NoError(t, err)
+
+	pages = 0
+	action, err := processor.GetActionForClaim(userCtx, "")
+	require.NoError(t, err)
+	require.True(t, action.Is(questclaim.Accepted), action.Message())
+
+	processor, err = questFactory.NewProcessor(ctx, quest, map[string]any{"channel_id": "general", "number": 21})
+	require.NoError(t, err)
+
+	pages = 0
+	_, err = processor.GetActionForClaim(userCtx, "")
+	require.Equal(t, errorx.New(errorx.Unavailable,
+		"The channel has too many messages to be verified, please contact the community"), err)
+}
+
+func Test_questFactory_TwitterReaction_QuoteAndSybil(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+
+	require.NoError(t, repository.NewOAuth2Repository().Create(ctx, &entity.OAuth2{
+		UserID:          testutil.User1.ID,
+		Service:         "twitter",
+		ServiceUserID:   "twitter_user1",
+		ServiceUsername: "alice",
+	}))
+
+	questFactory := testutil.NewQuestFactoryWithMocks(ctx, testutil.QuestFactoryMocks{
+		TwitterEndpoint: &testutil.MockTwitterEndpoint{
+			GetTweetFunc: func(ctx context.Context, author, tweetID string) (twitter.Tweet, error) {
+				return twitter.Tweet{ID: tweetID, Author: author}, nil
+			},
+			GetUserFunc: func(ctx context.Context, handle string) (twitter.User, error) {
+				return twitter.User{
+					Handle:         handle,
+					FollowersCount: 100,
+					CreatedAt:      time.Now().AddDate(0, 0, -30).Unix(),
+				}, nil
+			},
+			CheckAndGetQuoteFunc: func(ctx context.Context, author, tweetID, quoteTo string) (twitter.Tweet, error) {
+				if tweetID != "quote" || quoteTo != "origin" {
+					return twitter.Tweet{}, errors.New("not a quote")
+				}
+
+				return twitter.Tweet{ID: tweetID, Author: author, Text: "Join #QuestX now", QuotedTweetID: quoteTo}, nil
+			},
+		},
+	})
+
+	quest := *testutil.Quest1
+	quest.Type = entity.QuestTwitterReaction
+
+	_, err := questFactory.NewProcessor(ctx, quest, map[string]any{
+		"tweet_url": "https://twitter.com/questx/status/origin",
+		"reply":     true,
+		"quote":     true,
+	})
+	require.Equal(t, errorx.New(errorx.BadRequest, "Cannot require both reply and quote"), err)
+
+	tests := []struct {
+		name           string
+		data           map[string]any
+		submissionData string
+		want           questclaim.ActionForClaim
+		wantMessage    string
+	}{
+		{
+			name:           "quote with included words",
+			data:           map[string]any{"quote": true, "included_words": []string{"#questx"}},
+			submissionData: "https://twitter.com/alice/status/quote",
+			want:           questclaim.Accepted,
+		},
+		{
+			name:           "quote missing included words",
+			data:           map[string]any{"quote": true, "included_words": []string{"#airdrop"}},
+			submissionData: "https://twitter.com/alice/status/quote",
+			want:           questclaim.Rejected,
+			wantMessage:    "The quote tweet doesn't include \"#airdrop\"",
+		},
+		{
+			name:           "quote of another user",
+			data:           map[string]any{"quote": true},
+			submissionData: "https://twitter.com/bob/status/quote",
+			want:           questclaim.Rejected,
+			wantMessage:    "The quote tweet is not yours",
+		},
+		{
+			name:           "not a quote",
+			data:           map[string]any{"quote": true},
+			submissionData: "https://twitter.com/alice/status/other",
+			want:           questclaim.Rejected,
+			wantMessage:    "User has not quoted the tweet",
+		},
+		{
+			name: "enough followers and account age",
+			data: map[string]any{"like": true, "min_followers": 100, "min_account_age_days": 30},
+			want: questclaim.Accepted,
+		},
+		{
+			name:        "not enough followers",
+			data:        map[string]any{"like": true, "min_followers": 101},
+			want:        questclaim.Rejected,
+			wantMessage: "Not enough followers (got 100, but expected 101)",
+		},
+		{
+			name:        "too young account",
+			data:        map[string]any{"like": true, "min_account_age_days": 31},
+			want:        questclaim.Rejected,
+			wantMessage: "Your twitter account must be at least 31 days old",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data["tweet_url"] = "https://twitter.com/questx/status/origin"
+			processor, err := questFactory.NewProcessor(ctx, quest, tt.data)
+			require.NoError(t, err)
+
+			action, err := processor.GetActionForClaim(
+				xcontext.WithRequestUserID(ctx, testutil.User1.ID), tt.submissionData)
+			require.NoError(t, err)
+			require.True(t, action.Is(tt.want), action.Message())
+			require.Equal(t, tt.wantMessage, action.Message())
+		})
+	}
+}
+
+func Test_questFactory_ChatEngagement(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+
+	require.NoError(t, repository.NewChatChannelRepository().Create(ctx, &entity.ChatChannel{
+		SnowFlakeBase: entity.SnowFlakeBase{ID: 1},
+		CommunityID:   testutil.Community1.ID,
+		Name:          "general",
+	}))
+
+	// Every page is full and contains one message of user1, which is also
+	// reacted by user1.
+	totalPages := 0
+	reactedMessages := map[int64]bool{}
+	chatMessageRepo := &testutil.MockChatMessageRepository{
+		GetListByLastMessageFunc: func(
+			ctx context.Context, filter repository.LastMessageFilter,
+		) ([]entity.ChatMessage, error) {
+			before := filter.Before
+			if before == 0 {
+				before = 1_000_000
+			}
+
+			if (1_000_000-before)/int64(filter.Limit) >= int64(totalPages) {
+				return nil, nil
+			}
+
+			messages := make([]entity.ChatMessage, filter.Limit)
+			for i := range messages {
+				messages[i] = entity.ChatMessage{ID: before - int64(i) - 1, AuthorID: testutil.User2.ID}
+			}
+			messages[0].AuthorID = testutil.User1.ID
+			reactedMessages[messages[0].ID] = true
+			return messages, nil
+		},
+	}
+
+	chatReactionRepo := &testutil.MockChatReactionRepository{
+		GetByMessageIDsFunc: func(ctx context.Context, messageIDs []int64) ([]entity.ChatReaction, error) {
+			reactions := []entity.ChatReaction{}
+			for _, id := range messageIDs {
+				if reactedMessages[id] {
+					reactions = append(reactions, entity.ChatReaction{
+						MessageID: id,
+						UserIds:   []string{testutil.User1.ID},
+					})
+				}
+			}
+			return reactions, nil
+		},
+	}
+
+	questFactory := testutil.NewQuestFactoryWithMocks(ctx, testutil.QuestFactoryMocks{
+		ChatMessageRepo:  chatMessageRepo,
+		ChatReactionRepo: chatReactionRepo,
+	})
+
+	quest := *testutil.Quest1
+	quest.Type = entity.QuestChatEngagement
+
+	_, err := questFactory.NewProcessor(ctx, quest, map[string]any{"channel_id": "2", "number": 1, "count_messages": true})
+	require.Equal(t, errorx.New(errorx.NotFound, "Not found channel"), err)
+
+	tests := []struct {
+		name       string
+		totalPages int
+		data       map[string]any
+		want       questclaim.ActionForClaim
+		wantErr    error
+	}{
+		{
+			name:       "enough messages",
+			totalPages: 3,
+			data:       map[string]any{"number": 3, "count_messages": true},
+			want:       questclaim.Accepted,
+		},
+		{
+			name:       "not enough messages",
+			totalPages: 3,
+			data:       map[string]any{"number": 4, "count_messages": true},
+			want:       questclaim.Rejected,
+		},
+		{
+			name:       "enough messages and reactions",
+			totalPages: 3,
+			data:       map[string]any{"number": 6, "count_messages": true, "count_reactions": true},
+			want:       questclaim.Accepted,
+		},
+		{
+			name:       "too many messages",
+			totalPages: 30,
+			data:       map[string]any{"number": 21, "count_messages": true},
+			wantErr: errorx.New(errorx.Unavailable,
+				"The channel has too many messages to be verified, please contact the community"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totalPages = tt.totalPages
+			tt.data["channel_id"] = "1"
+			processor, err := questFactory.NewProcessor(ctx, quest, tt.data)
+			require.NoError(t, err)
+
+			action, err := processor.GetActionForClaim(xcontext.WithRequestUserID(ctx, testutil.User1.ID), "")
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, action.Is(tt.want), action.Message())
+		})
+	}
+}
+
+func Test_questFactory_InviteMinInviteeQuests(t *testing.T) {
+	ctx := testutil.MockContext(t)
+	testutil.CreateFixtureDb(ctx)
+	questFactory := testutil.NewQuestFactory(ctx)
+
+	// User1 invited user2, user3 and user4 to community1. Only user2 and user3
+	// completed at least 10 quests.
+	require.NoError(t, xcontext.DB(ctx).Model(&entity.Follower{}).
+		Where("user_id=? AND community_id=?", testutil.User1.ID, testutil.Community1.ID).
+		Update("invite_count", 3).Error)
+	require.NoError(t, xcontext.DB(ctx).Model(&entity.Follower{}).
+		Where("user_id IN ? AND community_id=?",
+			[]string{testutil.User2.ID, testutil.User3.ID, testutil.User4.ID}, testutil.Community1.ID).
+		Update("invited_by", testutil.User1.ID).Error)
+	require.NoError(t, xcontext.DB(ctx).Model(&entity.Follower{}).
+		Where("user_id=? AND community_id=?", testutil.User4.ID, testutil.Community1.ID).
+		Update("quests", 2).Error)
+
+	quest := *testutil.Quest1
+	quest.Type = entity.QuestInvite
+
+	_, err := questFactory.NewProcessor(ctx, quest, map[string]any{"number": 1, "min_invitee_quests": -1})
+	require.Equal(t, errorx.New(errorx.BadRequest, "Minimum quests of invitee must not be negative"), err)
+
+	tests := []struct {
+		name             string
+		number           int
+		minInviteeQuests int
+		want             questclaim.ActionForClaim
+		wantMessage      string
+	}{
+		{name: "count all invitees", number: 3, want: questclaim.Accepted},
+		{
+			name:        "not enough invites",
+			number:      4,
+			want:        questclaim.Rejected,
+			wantMessage: "Not enough number of invites (got 3, but expected 4)",
+		},
+		{name: "enough qualified invitees", number: 2, minInviteeQuests: 10, want: questclaim.Accepted},
+		{
+			name:             "not enough qualified invitees",
+			number:           3,
+			minInviteeQuests: 10,
+			want:             questclaim.Rejected,
+			wantMessage:      "Not enough number of invitees completing at least 10 quests (got 2, but expected 3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := questFactory.NewProcessor(ctx, quest,
+				map[string]any{"number": tt.number, "min_invitee_quests": tt.minInviteeQuests})
+			require.NoError(t, err)
+
+			action, err := processor.GetActionForClaim(xcontext.WithRequestUserID(ctx, testutil.User1.ID), "")
+			require.NoError(t, err)
+			require.True(t, action.Is(tt.want), action.Message())
+			require.Equal(t, tt.wantMessage, action.Message())
+		})
+	}
+}
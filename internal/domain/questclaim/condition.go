@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
+	"github.com/fatih/structs"
 	"github.com/mitchellh/mapstructure"
 	"github.com/questx-lab/backend/internal/entity"
 	"github.com/questx-lab/backend/internal/repository"
+	"github.com/questx-lab/backend/pkg/dateutil"
 	"github.com/questx-lab/backend/pkg/enum"
 	"github.com/questx-lab/backend/pkg/errorx"
 	"github.com/questx-lab/backend/pkg/xcontext"
@@ -91,6 +95,7 @@ func (c *QuestCondition) Check(ctx context.Context) (bool, error) {
 			QuestID: c.QuestID,
 			Status: []entity.ClaimedQuestStatus{
 				entity.Pending,
+				entity.Appealed,
 				entity.Accepted,
 				entity.AutoAccepted,
 			},
@@ -308,3 +313,411 @@ func (c *discordCondition) Check(ctx context.Context) (bool, error) {
 		return false, errorx.New(errorx.BadRequest, "Invalid operator of Quest condition")
 	}
 }
+
+// NFT Condition
+type nftCondition struct {
+	TokenID   int64  `mapstructure:"token_id" structs:"token_id"`
+	TokenName string `mapstructure:"token_name" structs:"token_name"`
+	Amount    int    `mapstructure:"amount" structs:"amount"`
+
+	factory Factory
+}
+
+func newNFTCondition(
+	ctx context.Context,
+	factory Factory,
+	data map[string]any,
+	needParse bool,
+) (*nftCondition, error) {
+	condition := nftCondition{factory: factory}
+	err := mapstructure.Decode(data, &condition)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if condition.Amount <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Amount must be a positive")
+		}
+
+		nft, err := factory.nftRepo.GetByID(ctx, condition.TokenID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Not found NFT")
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get nft: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		condition.TokenName = nft.Name
+	}
+
+	return &condition, nil
+}
+
+func (c nftCondition) Statement() string {
+	return fmt.Sprintf("You must own at least %d %s to claim this quest", c.Amount, c.TokenName)
+}
+
+func (c *nftCondition) Check(ctx context.Context) (bool, error) {
+	balance, err := c.factory.getNFTBalance(ctx, xcontext.RequestUserID(ctx), c.TokenID, c.Amount)
+	if err != nil {
+		return false, err
+	}
+
+	return balance.Cmp(big.NewInt(int64(c.Amount))) >= 0, nil
+}
+
+// Follower Condition
+type followerCondition struct {
+	Value uint64 `mapstructure:"value" structs:"value"`
+
+	conditionType entity.ConditionType
+	communityID   string
+	factory       Factory
+}
+
+func newFollowerCondition(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	conditionType entity.ConditionType,
+	data map[string]any,
+	needParse bool,
+) (*followerCondition, error) {
+	condition := followerCondition{
+		conditionType: conditionType,
+		communityID:   quest.CommunityID.String,
+		factory:       factory,
+	}
+	err := mapstructure.Decode(data, &condition)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if condition.Value == 0 {
+			return nil, errorx.New(errorx.BadRequest, "Value of condition must be positive")
+		}
+	}
+
+	return &condition, nil
+}
+
+func (c followerCondition) Statement() string {
+	switch c.conditionType {
+	case entity.PointsCondition:
+		return fmt.Sprintf("You must have at least %d points to claim this quest", c.Value)
+	case entity.QuestCountCondition:
+		return fmt.Sprintf("You must complete at least %d quests to claim this quest", c.Value)
+	case entity.ChatLevelCondition:
+		return fmt.Sprintf("You must reach chat level %d to claim this quest", c.Value)
+	default:
+		return fmt.Sprintf("You must have a streak of at least %d days to claim this quest", c.Value)
+	}
+}
+
+func (c *followerCondition) Check(ctx context.Context) (bool, error) {
+	requestUserID := xcontext.RequestUserID(ctx)
+	if c.conditionType == entity.StreakCondition {
+		streak, err := c.factory.followerRepo.GetLastStreak(ctx, requestUserID, c.communityID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get last streak: %v", err)
+			return false, errorx.Unknown
+		}
+
+		// The streak is broken if user didn't claim any quest yesterday or
+		// today.
+		lastStreakDate := streak.StartTime.Add(time.Duration(streak.Streaks-1) * 24 * time.Hour)
+		if !dateutil.IsToday(lastStreakDate, time.Now()) && !dateutil.IsYesterday(lastStreakDate, time.Now()) {
+			return false, nil
+		}
+
+		return uint64(streak.Streaks) >= c.Value, nil
+	}
+
+	follower, err := c.factory.followerRepo.Get(ctx, requestUserID, c.communityID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get follower: %v", err)
+		return false, errorx.Unknown
+	}
+
+	switch c.conditionType {
+	case entity.PointsCondition:
+		return follower.Points >= c.Value, nil
+	case entity.QuestCountCondition:
+		return follower.Quests >= c.Value, nil
+	case entity.ChatLevelCondition:
+		return uint64(follower.ChatLevel) >= c.Value, nil
+	default:
+		return false, errorx.New(errorx.BadRequest, "Invalid type of follower condition")
+	}
+}
+
+// Badge Condition
+type badgeCondition struct {
+	Name  string `mapstructure:"name" structs:"name"`
+	Level int    `mapstructure:"level" structs:"level"`
+
+	communityID string
+	factory     Factory
+}
+
+func newBadgeCondition(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	data map[string]any,
+	needParse bool,
+) (*badgeCondition, error) {
+	condition := badgeCondition{communityID: quest.CommunityID.String, factory: factory}
+	err := mapstructure.Decode(data, &condition)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if condition.Level <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Level of badge must be positive")
+		}
+
+		_, err := factory.badgeRepo.Get(ctx, condition.communityID, condition.Name, condition.Level)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Not found badge %s level %d",
+					condition.Name, condition.Level)
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get badge: %v", err)
+			return nil, errorx.Unknown
+		}
+	}
+
+	return &condition, nil
+}
+
+func (c badgeCondition) Statement() string {
+	return fmt.Sprintf("You must own badge %s at least level %d to claim this quest", c.Name, c.Level)
+}
+
+func (c *badgeCondition) Check(ctx context.Context) (bool, error) {
+	badgeDetail, err := c.factory.badgeDetailRepo.GetLatest(
+		ctx, xcontext.RequestUserID(ctx), c.communityID, c.Name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		xcontext.Logger(ctx).Errorf("Cannot get latest badge detail: %v", err)
+		return false, errorx.Unknown
+	}
+
+	badge, err := c.factory.badgeRepo.GetByID(ctx, badgeDetail.BadgeID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get badge: %v", err)
+		return false, errorx.Unknown
+	}
+
+	return badge.Level >= c.Level, nil
+}
+
+// Role Condition
+type roleCondition struct {
+	RoleID   string `mapstructure:"role_id" structs:"role_id"`
+	RoleName string `mapstructure:"role_name" structs:"role_name"`
+
+	communityID string
+	factory     Factory
+}
+
+func newRoleCondition(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	data map[string]any,
+	needParse bool,
+) (*roleCondition, error) {
+	condition := roleCondition{communityID: quest.CommunityID.String, factory: factory}
+	err := mapstructure.Decode(data, &condition)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		role, err := factory.roleRepo.GetByID(ctx, condition.RoleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Not found role")
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get role: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if role.CommunityID.Valid && role.CommunityID.String != quest.CommunityID.String {
+			return nil, errorx.New(errorx.BadRequest, "Role doesn't belong to the community")
+		}
+
+		condition.RoleName = role.Name
+	}
+
+	return &condition, nil
+}
+
+func (c roleCondition) Statement() string {
+	return fmt.Sprintf("You must be role %s to claim this quest", c.RoleName)
+}
+
+func (c *roleCondition) Check(ctx context.Context) (bool, error) {
+	followerRoles, err := c.factory.followerRoleRepo.Get(ctx, xcontext.RequestUserID(ctx), c.communityID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get follower roles: %v", err)
+		return false, errorx.Unknown
+	}
+
+	for _, followerRole := range followerRoles {
+		if followerRole.RoleID == c.RoleID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Group Condition
+const maxConditionGroupDepth = 5
+
+type conditionNode struct {
+	Type string         `mapstructure:"type" structs:"type"`
+	Data map[string]any `mapstructure:"data" structs:"data"`
+}
+
+// groupCondition combines its child conditions with the operator and/or. A
+// child can be another group, so conditions can be nested arbitrarily.
+type groupCondition struct {
+	Op         string          `mapstructure:"op" structs:"op"`
+	Conditions []conditionNode `mapstructure:"conditions" structs:"conditions"`
+
+	conditions []Condition
+}
+
+func newGroupCondition(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	data map[string]any,
+	needParse bool,
+) (*groupCondition, error) {
+	condition := groupCondition{}
+	err := mapstructure.Decode(data, &condition)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if _, err := enum.ToEnum[entity.ConditionOpType](condition.Op); err != nil {
+			xcontext.Logger(ctx).Debugf("Invalid condition op: %v", err)
+			return nil, errorx.New(errorx.BadRequest, "Invalid condition op")
+		}
+
+		if len(condition.Conditions) == 0 {
+			return nil, errorx.New(errorx.BadRequest, "Condition group must not be empty")
+		}
+
+		if condition.depth() > maxConditionGroupDepth {
+			return nil, errorx.New(errorx.BadRequest,
+				"Condition groups are nested too deeply (maximum is %d)", maxConditionGroupDepth)
+		}
+	}
+
+	for i, node := range condition.Conditions {
+		conditionType, err := enum.ToEnum[entity.ConditionType](node.Type)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Invalid condition type: %v", err)
+			return nil, errorx.New(errorx.BadRequest, "Invalid condition type %s", node.Type)
+		}
+
+		child, err := factory.newCondition(ctx, quest, conditionType, node.Data, needParse)
+		if err != nil {
+			return nil, err
+		}
+
+		if needParse {
+			condition.Conditions[i].Data = structs.Map(child)
+		}
+
+		condition.conditions = append(condition.conditions, child)
+	}
+
+	return &condition, nil
+}
+
+// depth returns the number of nested levels of this group, including itself.
+func (c groupCondition) depth() int {
+	maxChildDepth := 0
+	for _, node := range c.Conditions {
+		if entity.ConditionType(node.Type) != entity.GroupCondition {
+			continue
+		}
+
+		child := groupCondition{}
+		if err := mapstructure.Decode(node.Data, &child); err != nil {
+			continue
+		}
+
+		if d := child.depth(); d > maxChildDepth {
+			maxChildDepth = d
+		}
+	}
+
+	return maxChildDepth + 1
+}
+
+func (c groupCondition) Statement() string {
+	statements := []string{}
+	for _, child := range c.conditions {
+		statement := child.Statement()
+		if _, ok := child.(*groupCondition); ok {
+			statement = "(" + statement + ")"
+		}
+
+		statements = append(statements, statement)
+	}
+
+	return strings.Join(statements, fmt.Sprintf(" %s ", strings.ToUpper(c.Op)))
+}
+
+func (c *groupCondition) Check(ctx context.Context) (bool, error) {
+	isAnd := entity.ConditionOpType(c.Op) == entity.And
+	for _, child := range c.conditions {
+		ok, err := child.Check(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		// Short-circuit evaluation.
+		if isAnd && !ok {
+			return false, nil
+		}
+
+		if !isAnd && ok {
+			return true, nil
+		}
+	}
+
+	return isAnd, nil
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/questx-lab/backend/internal/client"
+	"github.com/questx-lab/backend/internal/domain/badge"
 	"github.com/questx-lab/backend/internal/entity"
 	"github.com/questx-lab/backend/internal/repository"
 	"github.com/questx-lab/backend/pkg/api/discord"
 	"github.com/questx-lab/backend/pkg/api/telegram"
 	"github.com/questx-lab/backend/pkg/api/twitter"
 	"github.com/questx-lab/backend/pkg/dateutil"
+	"github.com/questx-lab/backend/pkg/errorx"
 	"github.com/questx-lab/backend/pkg/xcontext"
 	"gorm.io/gorm"
 )
@@ -24,6 +28,23 @@ const week = 7 * day
 var referralReward Reward
 var referralRewardMutex sync.Mutex
 
+type claimWalletAddressKey struct{}
+
+// WithClaimWalletAddress attaches the custom wallet address of the claim
+// request to context, so processors can validate it.
+func WithClaimWalletAddress(ctx context.Context, address string) context.Context {
+	return context.WithValue(ctx, claimWalletAddressKey{}, address)
+}
+
+func claimWalletAddress(ctx context.Context) string {
+	address := ctx.Value(claimWalletAddressKey{})
+	if address == nil {
+		return ""
+	}
+
+	return address.(string)
+}
+
 type Factory struct {
 	claimedQuestRepo repository.ClaimedQuestRepository
 	questRepo        repository.QuestRepository
@@ -35,10 +56,21 @@ type Factory struct {
 	blockchainRepo   repository.BlockChainRepository
 	lotteryRepo      repository.LotteryRepository
 	nftRepo          repository.NftRepository
+	chatChannelRepo  repository.ChatChannelRepository
+	chatMessageRepo  repository.ChatMessageRepository
+	chatReactionRepo repository.ChatReactionRepository
+	badgeRepo        repository.BadgeRepository
+	badgeDetailRepo  repository.BadgeDetailRepository
+	roleRepo         repository.RoleRepository
+	followerRoleRepo repository.FollowerRoleRepository
+
+	badgeManager *badge.Manager
 
 	twitterEndpoint  twitter.IEndpoint
 	discordEndpoint  discord.IEndpoint
 	telegramEndpoint telegram.IEndpoint
+
+	blockchainCaller client.BlockchainCaller
 }
 
 func NewFactory(
@@ -52,9 +84,18 @@ func NewFactory(
 	blockchainRepo repository.BlockChainRepository,
 	lotteryRepo repository.LotteryRepository,
 	nftRepo repository.NftRepository,
+	chatChannelRepo repository.ChatChannelRepository,
+	chatMessageRepo repository.ChatMessageRepository,
+	chatReactionRepo repository.ChatReactionRepository,
+	badgeRepo repository.BadgeRepository,
+	badgeDetailRepo repository.BadgeDetailRepository,
+	roleRepo repository.RoleRepository,
+	followerRoleRepo repository.FollowerRoleRepository,
+	badgeManager *badge.Manager,
 	twitterEndpoint twitter.IEndpoint,
 	discordEndpoint discord.IEndpoint,
 	telegramEndpoint telegram.IEndpoint,
+	blockchainCaller client.BlockchainCaller,
 ) Factory {
 	return Factory{
 		claimedQuestRepo: claimedQuestRepo,
@@ -67,9 +108,18 @@ func NewFactory(
 		blockchainRepo:   blockchainRepo,
 		lotteryRepo:      lotteryRepo,
 		nftRepo:          nftRepo,
+		chatChannelRepo:  chatChannelRepo,
+		chatMessageRepo:  chatMessageRepo,
+		chatReactionRepo: chatReactionRepo,
+		badgeRepo:        badgeRepo,
+		badgeDetailRepo:  badgeDetailRepo,
+		roleRepo:         roleRepo,
+		followerRoleRepo: followerRoleRepo,
+		badgeManager:     badgeManager,
 		twitterEndpoint:  twitterEndpoint,
 		discordEndpoint:  discordEndpoint,
 		telegramEndpoint: telegramEndpoint,
+		blockchainCaller: blockchainCaller,
 	}
 }
 
@@ -78,6 +128,54 @@ func (f Factory) NewProcessor(ctx context.Context, quest entity.Quest, data map[
 	return f.newProcessor(ctx, quest, data, true, true)
 }
 
+// LoadSurvey returns the questions of survey quest.
+func (f Factory) LoadSurvey(ctx context.Context, quest entity.Quest) ([]SurveyQuestion, error) {
+	if quest.Type != entity.QuestSurvey {
+		return nil, errorx.New(errorx.BadRequest, "Quest is not a survey")
+	}
+
+	survey, err := newSurveyProcessor(ctx, quest.ValidationData, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return survey.Questions, nil
+}
+
+// ParseSurveyAnswers validates the submission data of survey quest and returns
+// answers in structured form. The ClaimedQuestID of answers is not set.
+func (f Factory) ParseSurveyAnswers(
+	ctx context.Context, quest entity.Quest, submissionData string,
+) ([]entity.SurveyAnswer, error) {
+	if quest.Type != entity.QuestSurvey {
+		return nil, errorx.New(errorx.BadRequest, "Quest is not a survey")
+	}
+
+	survey, err := newSurveyProcessor(ctx, quest.ValidationData, false)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := survey.parseAnswers(ctx, submissionData)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []entity.SurveyAnswer{}
+	for i, answer := range answers {
+		for j, a := range answer {
+			result = append(result, entity.SurveyAnswer{
+				QuestID:  quest.ID,
+				Question: i,
+				Position: j,
+				Answer:   a,
+			})
+		}
+	}
+
+	return result, nil
+}
+
 // LoadProcessor creates a new processor but not validate the data.
 func (f Factory) LoadProcessor(ctx context.Context, includeSecret bool, quest entity.Quest, data map[string]any) (Processor, error) {
 	return f.newProcessor(ctx, quest, data, false, includeSecret)
@@ -101,6 +199,10 @@ func (f Factory) newProcessor(
 
 	case entity.QuestQuiz:
 		processor, err = newQuizProcessor(ctx, data, needParse)
+
+	case entity.QuestSurvey:
+		processor, err = newSurveyProcessor(ctx, data, needParse)
+
 	case entity.QuestEmpty:
 		processor, err = newEmptyProcessor(ctx, data)
 
@@ -128,12 +230,30 @@ func (f Factory) newProcessor(
 	case entity.QuestInviteDiscord:
 		processor, err = newInviteDiscordProcessor(ctx, f, quest, data, needParse)
 
+	case entity.QuestDiscordMessage:
+		processor, err = newDiscordMessageProcessor(ctx, f, quest, data, needParse)
+
 	case entity.QuestJoinTelegram:
 		processor, err = newJoinTelegramProcessor(ctx, f, quest, data, needParse)
 
 	case entity.QuestInvite:
 		processor, err = newInviteProcessor(ctx, f, quest, data, needParse)
 
+	case entity.QuestChatEngagement:
+		processor, err = newChatEngagementProcessor(ctx, f, quest, data, needParse)
+
+	case entity.QuestHoldERC20:
+		processor, err = newHoldERC20Processor(ctx, f, data, needParse)
+
+	case entity.QuestHoldNFT:
+		processor, err = newHoldNFTProcessor(ctx, f, data, needParse)
+
+	case entity.QuestContractInteraction:
+		processor, err = newContractInteractionProcessor(ctx, f, quest, data, needParse)
+
+	case entity.QuestSignMessage:
+		processor, err = newSignMessageProcessor(ctx, f, quest, data, needParse)
+
 	default:
 		return nil, fmt.Errorf("invalid quest type %s", quest.Type)
 	}
@@ -184,6 +304,21 @@ func (f Factory) newCondition(
 	case entity.DiscordCondition:
 		condition, err = newDiscordCondition(ctx, f, quest, data, needParse)
 
+	case entity.NFTCondition:
+		condition, err = newNFTCondition(ctx, f, data, needParse)
+
+	case entity.GroupCondition:
+		condition, err = newGroupCondition(ctx, f, quest, data, needParse)
+
+	case entity.PointsCondition, entity.QuestCountCondition, entity.ChatLevelCondition, entity.StreakCondition:
+		condition, err = newFollowerCondition(ctx, f, quest, conditionType, data, needParse)
+
+	case entity.BadgeCondition:
+		condition, err = newBadgeCondition(ctx, f, quest, data, needParse)
+
+	case entity.RoleCondition:
+		condition, err = newRoleCondition(ctx, f, quest, data, needParse)
+
 	default:
 		return nil, fmt.Errorf("invalid condition type %s", conditionType)
 	}
@@ -234,6 +369,15 @@ func (f Factory) newReward(
 	case entity.NFTReward:
 		reward, err = newNonFungibleTokenReward(ctx, f, data, needParse)
 
+	case entity.BadgeReward:
+		reward, err = newBadgeReward(ctx, communityID, f, data, needParse)
+
+	case entity.RoleReward:
+		reward, err = newRoleReward(ctx, communityID, f, data, needParse)
+
+	case entity.LotteryReward:
+		reward, err = newLotteryTicketReward(ctx, communityID, f, data, needParse)
+
 	default:
 		return nil, fmt.Errorf("invalid reward type %s", rewardType)
 	}
@@ -267,6 +411,46 @@ func (f Factory) getRequestServiceUserID(ctx context.Context, service string) st
 	return id
 }
 
+// getNFTBalance returns the number of NFT the user is owning. If the user has
+// claimed at least the required amount from quest rewards, that amount is
+// returned without querying the chain. Otherwise, the balance is read on chain,
+// a user who has not connected to any wallet is considered to own nothing.
+func (f Factory) getNFTBalance(ctx context.Context, userID string, tokenID int64, required int) (*big.Int, error) {
+	claimed, err := f.nftRepo.GetClaimedToken(ctx, userID, tokenID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		xcontext.Logger(ctx).Errorf("Cannot get claimed nft: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if claimed != nil && claimed.Amount >= required {
+		return big.NewInt(int64(claimed.Amount)), nil
+	}
+
+	user, err := f.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get user: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if !user.WalletAddress.Valid || user.WalletAddress.String == "" {
+		return big.NewInt(0), nil
+	}
+
+	nft, err := f.nftRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get nft: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	balance, err := f.blockchainCaller.ERC1155BalanceOf(ctx, nft.Chain, user.WalletAddress.String, tokenID)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot get nft balance of %s: %v", user.WalletAddress.String, err)
+		return nil, errorx.New(errorx.Unavailable, "Cannot verify your nft balance")
+	}
+
+	return balance, nil
+}
+
 type UnclaimableReasonType int
 
 const (
@@ -274,6 +458,8 @@ const (
 	UnclaimableByRetryAfter
 	UnclaimableByCondition
 	UnclaimableByRecurrence
+	UnclaimableByLimit
+	UnclaimableBySchedule
 )
 
 type UnclaimableReason struct {
@@ -282,7 +468,32 @@ type UnclaimableReason struct {
 	Metadata map[string]any
 }
 
+// CheckSchedule returns the reason if the quest is out of its time window.
+func CheckSchedule(quest entity.Quest) *UnclaimableReason {
+	if quest.StartAt.Valid && time.Now().Before(quest.StartAt.Time) {
+		return &UnclaimableReason{
+			Type:     UnclaimableBySchedule,
+			Message:  "This quest has not started yet",
+			Metadata: map[string]any{"start_at": quest.StartAt.Time},
+		}
+	}
+
+	if quest.EndAt.Valid && !time.Now().Before(quest.EndAt.Time) {
+		return &UnclaimableReason{
+			Type:    UnclaimableBySchedule,
+			Message: "This quest has ended",
+		}
+	}
+
+	return nil
+}
+
 func (f Factory) IsClaimable(ctx context.Context, quest entity.Quest) (*UnclaimableReason, error) {
+	// Check the time window of quest.
+	if reason := CheckSchedule(quest); reason != nil {
+		return reason, nil
+	}
+
 	// Check time for reclaiming.
 	lastRejectedClaimedQuest, err := f.claimedQuestRepo.GetLast(
 		ctx,
@@ -351,6 +562,37 @@ func (f Factory) IsClaimable(ctx context.Context, quest entity.Quest) (*Unclaima
 		}, nil
 	}
 
+	// Check limit of claims.
+	if quest.MaxClaims > 0 && quest.ClaimedCount >= quest.MaxClaims {
+		return &UnclaimableReason{
+			Type:    UnclaimableByLimit,
+			Message: "This quest has no remaining slots",
+		}, nil
+	}
+
+	if quest.MaxClaimsPerUser > 0 {
+		claims, err := f.claimedQuestRepo.Count(ctx, repository.StatisticClaimedQuestFilter{
+			QuestID: quest.ID,
+			UserID:  xcontext.RequestUserID(ctx),
+			Status: []entity.ClaimedQuestStatus{
+				entity.Pending,
+				entity.Appealed,
+				entity.Accepted,
+				entity.AutoAccepted,
+			},
+		})
+		if err != nil {
+			return &UnclaimableReason{Type: UnclaimableByUnknown}, err
+		}
+
+		if claims >= int64(quest.MaxClaimsPerUser) {
+			return &UnclaimableReason{
+				Type:    UnclaimableByLimit,
+				Message: "You have reached the maximum number of claims for this quest",
+			}, nil
+		}
+	}
+
 	// Check recurrence.
 	lastClaimedQuest, err := f.claimedQuestRepo.GetLast(
 		ctx,
@@ -359,6 +601,7 @@ func (f Factory) IsClaimable(ctx context.Context, quest entity.Quest) (*Unclaima
 			QuestID: quest.ID,
 			Status: []entity.ClaimedQuestStatus{
 				entity.Pending,
+				entity.Appealed,
 				entity.Accepted,
 				entity.AutoAccepted,
 			},
@@ -384,7 +627,7 @@ func (f Factory) IsClaimable(ctx context.Context, quest entity.Quest) (*Unclaima
 		}, nil
 
 	case entity.Daily:
-		if lastClaimedAt.Day() != time.Now().Day() || time.Since(lastClaimedAt) > day {
+		if !InCurrentRecurrence(quest.Recurrence, lastClaimedAt) {
 			return nil, nil
 		}
 
@@ -395,9 +638,7 @@ func (f Factory) IsClaimable(ctx context.Context, quest entity.Quest) (*Unclaima
 		}, nil
 
 	case entity.Weekly:
-		_, lastWeek := lastClaimedAt.ISOWeek()
-		_, currentWeek := time.Now().ISOWeek()
-		if lastWeek != currentWeek || time.Since(lastClaimedAt) > week {
+		if !InCurrentRecurrence(quest.Recurrence, lastClaimedAt) {
 			return nil, nil
 		}
 
@@ -408,7 +649,7 @@ func (f Factory) IsClaimable(ctx context.Context, quest entity.Quest) (*Unclaima
 		}, nil
 
 	case entity.Monthly:
-		if lastClaimedAt.Month() != time.Now().Month() || lastClaimedAt.Year() != time.Now().Year() {
+		if !InCurrentRecurrence(quest.Recurrence, lastClaimedAt) {
 			return nil, nil
 		}
 
@@ -423,6 +664,29 @@ func (f Factory) IsClaimable(ctx context.Context, quest entity.Quest) (*Unclaima
 	}
 }
 
+// InCurrentRecurrence returns true if the time is in the current recurrence
+// period. A quest with once recurrence has only one period.
+func InCurrentRecurrence(recurrence entity.RecurrenceType, t time.Time) bool {
+	now := time.Now()
+	switch recurrence {
+	case entity.Once:
+		return true
+
+	case entity.Daily:
+		return t.Day() == now.Day() && now.Sub(t) <= day
+
+	case entity.Weekly:
+		_, lastWeek := t.ISOWeek()
+		_, currentWeek := now.ISOWeek()
+		return lastWeek == currentWeek && now.Sub(t) <= week
+
+	case entity.Monthly:
+		return t.Month() == now.Month() && t.Year() == now.Year()
+	}
+
+	return false
+}
+
 func (f Factory) LoadReferralReward(ctx context.Context) (Reward, error) {
 	if referralReward == nil {
 		referralRewardMutex.Lock()
@@ -55,6 +55,23 @@ type Processor interface {
 	RetryAfter() time.Duration
 }
 
+// SubmissionNormalizer is a processor which accepts many representations of
+// the same submission data (e.g. the letter case of a transaction hash). The
+// submission data must be normalized before it is processed and stored.
+type SubmissionNormalizer interface {
+	NormalizeSubmissionData(submissionData string) string
+}
+
+// NormalizeSubmissionData returns the canonical form of submission data if the
+// processor supports it, otherwise, returns the submission data as is.
+func NormalizeSubmissionData(processor Processor, submissionData string) string {
+	if normalizer, ok := processor.(SubmissionNormalizer); ok {
+		return normalizer.NormalizeSubmissionData(submissionData)
+	}
+
+	return submissionData
+}
+
 // Condition is the prerequisite to claim the quest.
 type Condition interface {
 	// Always return errorx in this method.
@@ -74,3 +91,12 @@ type Reward interface {
 	WithLotteryWinner(winner *entity.LotteryWinner)
 	WithWalletAddress(address string)
 }
+
+// RevocableReward is a reward which can be taken back from user when the
+// claimed quest is unapproved.
+type RevocableReward interface {
+	Reward
+
+	// Always return errorx in this method.
+	Revoke(ctx context.Context) error
+}
@@ -3,15 +3,27 @@ package questclaim
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"math/big"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/mitchellh/mapstructure"
 	"github.com/questx-lab/backend/internal/common"
 	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/internal/repository"
+	"github.com/questx-lab/backend/pkg/api/discord"
+	"github.com/questx-lab/backend/pkg/enum"
 	"github.com/questx-lab/backend/pkg/errorx"
+	"github.com/questx-lab/backend/pkg/ethutil"
+	"github.com/questx-lab/backend/pkg/numberutil"
 	"github.com/questx-lab/backend/pkg/xcontext"
+	"golang.org/x/exp/slices"
+	"gorm.io/gorm"
 )
 
 // URL Processor
@@ -210,6 +222,189 @@ func (p *quizProcessor) GetActionForClaim(ctx context.Context, submissionData st
 	return Accepted, nil
 }
 
+// Survey Processor
+const (
+	surveyMinRating     = 2
+	surveyMaxRating     = 10
+	surveyMaxTextLength = 1024
+)
+
+type SurveyQuestion struct {
+	Question  string   `mapstructure:"question" structs:"question"`
+	Type      string   `mapstructure:"type" structs:"type"`
+	Options   []string `mapstructure:"options" structs:"options"`
+	MaxRating int      `mapstructure:"max_rating" structs:"max_rating"`
+	Required  bool     `mapstructure:"required" structs:"required"`
+}
+
+// surveyAnswers is the submission data of survey quest. Each element is the
+// list of answers of the corresponding question, an empty list means the
+// question is skipped.
+type surveyAnswers struct {
+	Answers [][]string `json:"answers"`
+}
+
+type surveyProcessor struct {
+	Questions []SurveyQuestion `mapstructure:"questions" structs:"questions"`
+}
+
+func newSurveyProcessor(ctx context.Context, data map[string]any, needParse bool) (*surveyProcessor, error) {
+	survey := surveyProcessor{}
+	err := mapstructure.Decode(data, &survey)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	cfg := xcontext.Configs(ctx)
+	if needParse {
+		if len(survey.Questions) == 0 {
+			return nil, errorx.New(errorx.BadRequest, "Provide at least one question")
+		}
+
+		if len(survey.Questions) > cfg.Quest.QuizMaxQuestions {
+			return nil, errorx.New(errorx.BadRequest, "Too many questions")
+		}
+
+		for i, q := range survey.Questions {
+			if q.Question == "" {
+				return nil, errorx.New(errorx.BadRequest, "Question %d is empty", i+1)
+			}
+
+			questionType, err := enum.ToEnum[entity.SurveyQuestionType](q.Type)
+			if err != nil {
+				xcontext.Logger(ctx).Debugf("Invalid question type: %v", err)
+				return nil, errorx.New(errorx.BadRequest, "Invalid type of question %d", i+1)
+			}
+
+			switch questionType {
+			case entity.SurveySingleChoice, entity.SurveyMultipleChoice:
+				if len(q.Options) < 2 {
+					return nil, errorx.New(errorx.BadRequest, "Provide at least two options")
+				}
+
+				if len(q.Options) > cfg.Quest.QuizMaxOptions {
+					return nil, errorx.New(errorx.BadRequest, "Too many options")
+				}
+
+				for j, option := range q.Options {
+					if option == "" {
+						return nil, errorx.New(errorx.BadRequest, "Option of question %d is empty", i+1)
+					}
+
+					if slices.Contains(q.Options[:j], option) {
+						return nil, errorx.New(errorx.BadRequest, "Duplicated option %s", option)
+					}
+				}
+
+				survey.Questions[i].MaxRating = 0
+
+			case entity.SurveyRating:
+				if q.MaxRating < surveyMinRating || q.MaxRating > surveyMaxRating {
+					return nil, errorx.New(errorx.BadRequest,
+						"Max rating must be between %d and %d", surveyMinRating, surveyMaxRating)
+				}
+
+				survey.Questions[i].Options = nil
+
+			case entity.SurveyText:
+				survey.Questions[i].Options = nil
+				survey.Questions[i].MaxRating = 0
+			}
+		}
+	}
+
+	return &survey, nil
+}
+
+func (p surveyProcessor) RetryAfter() time.Duration {
+	return 0
+}
+
+func (p *surveyProcessor) GetActionForClaim(ctx context.Context, submissionData string) (ActionForClaim, error) {
+	if _, err := p.parseAnswers(ctx, submissionData); err != nil {
+		return nil, err
+	}
+
+	// Survey has no correct answer, any valid submission is accepted.
+	return Accepted, nil
+}
+
+// parseAnswers validates the submission data and returns the answers of each
+// question.
+func (p *surveyProcessor) parseAnswers(ctx context.Context, submissionData string) ([][]string, error) {
+	answers := surveyAnswers{}
+	err := json.Unmarshal([]byte(submissionData), &answers)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Cannot unmarshal submission data: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid submission data")
+	}
+
+	if len(answers.Answers) != len(p.Questions) {
+		return nil, errorx.New(errorx.BadRequest, "Invalid number of answers")
+	}
+
+	for i, answer := range answers.Answers {
+		q := p.Questions[i]
+		if len(answer) == 0 {
+			if q.Required {
+				return nil, errorx.New(errorx.BadRequest, "Question %d is required", i+1)
+			}
+
+			continue
+		}
+
+		switch entity.SurveyQuestionType(q.Type) {
+		case entity.SurveySingleChoice:
+			if len(answer) != 1 || !slices.Contains(q.Options, answer[0]) {
+				return nil, errorx.New(errorx.BadRequest, "Invalid answer at question %d", i+1)
+			}
+
+		case entity.SurveyMultipleChoice:
+			for j, option := range answer {
+				if !slices.Contains(q.Options, option) || slices.Contains(answer[:j], option) {
+					return nil, errorx.New(errorx.BadRequest, "Invalid answer at question %d", i+1)
+				}
+			}
+
+		case entity.SurveyRating:
+			if len(answer) != 1 {
+				return nil, errorx.New(errorx.BadRequest, "Invalid answer at question %d", i+1)
+			}
+
+			rating, err := strconv.Atoi(answer[0])
+			if err != nil || rating < 1 || rating > q.MaxRating {
+				return nil, errorx.New(errorx.BadRequest, "Rating of question %d must be between 1 and %d",
+					i+1, q.MaxRating)
+			}
+
+		case entity.SurveyText:
+			if len(answer) != 1 {
+				return nil, errorx.New(errorx.BadRequest, "Invalid answer at question %d", i+1)
+			}
+
+			answer[0] = strings.TrimSpace(answer[0])
+			if len(answer[0]) > surveyMaxTextLength {
+				return nil, errorx.New(errorx.BadRequest, "Answer of question %d is too long", i+1)
+			}
+
+			if answer[0] == "" {
+				if q.Required {
+					return nil, errorx.New(errorx.BadRequest, "Question %d is required", i+1)
+				}
+
+				answers.Answers[i] = nil
+			}
+
+		default:
+			xcontext.Logger(ctx).Errorf("Invalid question type in database: %s", q.Type)
+			return nil, errorx.Unknown
+		}
+	}
+
+	return answers.Answers, nil
+}
+
 // Image Processor
 type imageProcessor struct{}
 
@@ -312,10 +507,19 @@ type twitterReactionProcessor struct {
 	Like    bool `mapstructure:"like" structs:"like"`
 	Retweet bool `mapstructure:"retweet" structs:"retweet"`
 	Reply   bool `mapstructure:"reply" structs:"reply"`
+	Quote   bool `mapstructure:"quote" structs:"quote"`
 
 	TweetURL     string `mapstructure:"tweet_url" structs:"tweet_url"`
 	DefaultReply string `mapstructure:"default_reply" structs:"default_reply"`
 
+	// IncludedWords are words or hashtags which the quote tweet must contain.
+	IncludedWords []string `mapstructure:"included_words" structs:"included_words"`
+
+	// MinFollowers and MinAccountAgeDays are thresholds of the twitter account
+	// of claimer, they help to reject farm accounts.
+	MinFollowers      int `mapstructure:"min_followers" structs:"min_followers"`
+	MinAccountAgeDays int `mapstructure:"min_account_age_days" structs:"min_account_age_days"`
+
 	retryAfter  time.Duration
 	originTweet tweet
 	factory     Factory
@@ -338,6 +542,22 @@ func newTwitterReactionProcessor(
 	}
 
 	if needParse {
+		if twitterReaction.Reply && twitterReaction.Quote {
+			return nil, errorx.New(errorx.BadRequest, "Cannot require both reply and quote")
+		}
+
+		if len(twitterReaction.IncludedWords) > 0 && !twitterReaction.Quote {
+			return nil, errorx.New(errorx.BadRequest, "Included words are only used for quote")
+		}
+
+		if twitterReaction.MinFollowers < 0 {
+			return nil, errorx.New(errorx.BadRequest, "Invalid minimum number of followers")
+		}
+
+		if twitterReaction.MinAccountAgeDays < 0 {
+			return nil, errorx.New(errorx.BadRequest, "Invalid minimum account age")
+		}
+
 		remoteTweet, err := factory.twitterEndpoint.GetTweet(ctx, tweet.UserScreenName, tweet.TweetID)
 		if err != nil {
 			xcontext.Logger(ctx).Warnf("Cannot get tweet: %v", err)
@@ -365,9 +585,56 @@ func (p *twitterReactionProcessor) GetActionForClaim(ctx context.Context, submis
 		return nil, errorx.New(errorx.Unavailable, "User has not connected to twitter")
 	}
 
+	if p.MinFollowers > 0 || p.MinAccountAgeDays > 0 {
+		user, err := p.factory.twitterEndpoint.GetUser(ctx, userScreenName)
+		if err != nil {
+			xcontext.Logger(ctx).Warnf("Cannot get twitter user: %v", err)
+			return nil, errorx.New(errorx.Unavailable, "Cannot verify your twitter account")
+		}
+
+		if user.FollowersCount < p.MinFollowers {
+			return Rejected.WithMessage(
+				"Not enough followers (got %d, but expected %d)", user.FollowersCount, p.MinFollowers), nil
+		}
+
+		if p.MinAccountAgeDays > 0 {
+			minCreatedAt := time.Now().AddDate(0, 0, -p.MinAccountAgeDays)
+			if user.CreatedAt == 0 || time.Unix(user.CreatedAt, 0).After(minCreatedAt) {
+				return Rejected.WithMessage(
+					"Your twitter account must be at least %d days old", p.MinAccountAgeDays), nil
+			}
+		}
+	}
+
 	// NOTE: We don't need to check if tweet was liked and retweeted by user
 	// because scraper cannot check it easily.
 
+	if p.Quote {
+		quoteTweetURL, err := parseTweetURL(submissionData)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Invalid submission tweet url: %v", err)
+			return nil, errorx.New(errorx.BadRequest, "Invalid quote url")
+		}
+
+		if quoteTweetURL.UserScreenName != userScreenName {
+			return Rejected.WithMessage("The quote tweet is not yours"), nil
+		}
+
+		quote, err := p.factory.twitterEndpoint.CheckAndGetQuote(
+			ctx, userScreenName, quoteTweetURL.TweetID, p.originTweet.TweetID)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Cannot check quote: %v", err)
+			return Rejected.WithMessage("User has not quoted the tweet"), nil
+		}
+
+		text := strings.ToLower(quote.Text)
+		for _, word := range p.IncludedWords {
+			if !strings.Contains(text, strings.ToLower(word)) {
+				return Rejected.WithMessage("The quote tweet doesn't include \"%s\"", word), nil
+			}
+		}
+	}
+
 	if p.Reply {
 		originTweetURL, err := parseTweetURL(p.TweetURL)
 		if err != nil {
@@ -670,6 +937,195 @@ func (p *inviteDiscordProcessor) GetActionForClaim(
 	return Accepted, nil
 }
 
+// Discord Message Processor
+const (
+	discordMessagePageSize = 100
+	discordMessageMaxPages = 20
+)
+
+type discordMessageProcessor struct {
+	ChannelID string   `mapstructure:"channel_id" structs:"channel_id"`
+	Number    int      `mapstructure:"number" structs:"number"`
+	Keywords  []string `mapstructure:"keywords" structs:"keywords"`
+	Days      int      `mapstructure:"days" structs:"days"`
+	GuildID   string   `mapstructure:"guild_id" structs:"guild_id"`
+
+	retryAfter time.Duration
+	startTime  time.Time
+	factory    Factory
+}
+
+func newDiscordMessageProcessor(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	data map[string]any,
+	needParse bool,
+) (*discordMessageProcessor, error) {
+	discordMessage := discordMessageProcessor{}
+	err := mapstructure.Decode(data, &discordMessage)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if discordMessage.Number <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Invalid number of messages")
+		}
+
+		if discordMessage.Days < 0 {
+			return nil, errorx.New(errorx.BadRequest, "Invalid number of days")
+		}
+
+		if discordMessage.ChannelID == "" {
+			return nil, errorx.New(errorx.BadRequest, "Not found channel id")
+		}
+
+		keywords := []string{}
+		for _, keyword := range discordMessage.Keywords {
+			keyword = strings.TrimSpace(keyword)
+			if keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+		discordMessage.Keywords = keywords
+
+		community, err := factory.communityRepo.GetByID(ctx, quest.CommunityID.String)
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot get community: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if community.Discord == "" {
+			return nil, errorx.New(errorx.Unavailable, "Community hasn't connected to discord server")
+		}
+
+		hasAddBot, err := factory.discordEndpoint.HasAddedBot(ctx, community.Discord)
+		if err != nil {
+			xcontext.Logger(ctx).Warnf("Cannot call hasAddedBot api: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if !hasAddBot {
+			return nil, errorx.New(errorx.Unavailable, "Community hasn't added bot to discord server")
+		}
+
+		channel, err := factory.discordEndpoint.GetChannel(ctx, discordMessage.ChannelID)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Cannot get channel: %v", err)
+			return nil, errorx.New(errorx.NotFound, "Not found the channel")
+		}
+
+		if channel.GuildID != community.Discord {
+			return nil, errorx.New(errorx.BadRequest, "The channel doesn't belong to discord server of community")
+		}
+
+		// Ensure that the bot can read messages of this channel.
+		_, err = factory.discordEndpoint.GetChannelMessages(ctx, discordMessage.ChannelID, "0", 1)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Cannot get channel messages: %v", err)
+			return nil, errorx.New(errorx.Unavailable, "Bot cannot read messages of the channel")
+		}
+
+		discordMessage.GuildID = community.Discord
+	}
+
+	discordMessage.retryAfter = xcontext.Configs(ctx).Quest.Dicord.ReclaimDelay
+	discordMessage.startTime = quest.CreatedAt
+	if quest.StartAt.Valid {
+		discordMessage.startTime = quest.StartAt.Time
+	}
+	discordMessage.factory = factory
+	return &discordMessage, nil
+}
+
+func (p *discordMessageProcessor) RetryAfter() time.Duration {
+	return p.retryAfter
+}
+
+func (p *discordMessageProcessor) GetActionForClaim(
+	ctx context.Context, submissionData string,
+) (ActionForClaim, error) {
+	userDiscordID := p.factory.getRequestServiceUserID(ctx, xcontext.Configs(ctx).Auth.Discord.Name)
+	if userDiscordID == "" {
+		return nil, errorx.New(errorx.Unavailable, "User has not connected to discord")
+	}
+
+	since := p.startTime
+	if p.Days > 0 {
+		since = time.Now().AddDate(0, 0, -p.Days)
+	}
+
+	count := 0
+	completed := false
+	afterID := discord.SnowflakeFromTime(since)
+	for page := 0; page < discordMessageMaxPages && count < p.Number; page++ {
+		messages, err := p.factory.discordEndpoint.GetChannelMessages(
+			ctx, p.ChannelID, afterID, discordMessagePageSize)
+		if err != nil {
+			if resetAt, ok := discord.IsRateLimit(err); ok {
+				return nil, errorx.New(errorx.TooManyRequests,
+					"Discord is busy, please try again after %s", time.Until(resetAt).Round(time.Second))
+			}
+
+			xcontext.Logger(ctx).Debugf("Failed to get channel messages: %v", err)
+			return Rejected.WithMessage("Unable to get messages of the discord channel"), nil
+		}
+
+		var latest time.Time
+		for _, msg := range messages {
+			if msg.Timestamp.After(latest) {
+				latest = msg.Timestamp
+				afterID = msg.ID
+			}
+
+			if msg.Author.ID == userDiscordID && p.containsKeywords(msg.Content) {
+				count++
+			}
+		}
+
+		if len(messages) < discordMessagePageSize {
+			completed = true
+			break
+		}
+	}
+
+	// Discord returns messages from the oldest one, completed is false if
+	// discordMessageMaxPages pages were fetched without reaching the latest
+	// message of the channel.
+	return scannedCountResult(count, p.Number, completed, "messages")
+}
+
+// scannedCountResult returns the action for a claim which requires at least
+// the expected number of items counted by scanning a channel. If the scan
+// stopped at its limit before the count was enough, the user is not rejected
+// based on a partial count.
+func scannedCountResult(count, expected int, completed bool, what string) (ActionForClaim, error) {
+	if count >= expected {
+		return Accepted, nil
+	}
+
+	if !completed {
+		return nil, errorx.New(errorx.Unavailable,
+			"The channel has too many messages to be verified, please contact the community")
+	}
+
+	return Rejected.WithMessage(
+		"Not enough number of %s (got %d, but expected %d)", what, count, expected), nil
+}
+
+func (p *discordMessageProcessor) containsKeywords(content string) bool {
+	content = strings.ToLower(content)
+	for _, keyword := range p.Keywords {
+		if !strings.Contains(content, strings.ToLower(keyword)) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Join Telegram Processor
 type joinTelegramProcessor struct {
 	GroupLink string `mapstructure:"group_link" structs:"group_link"`
@@ -744,43 +1200,203 @@ func (p *joinTelegramProcessor) GetActionForClaim(ctx context.Context, submissio
 	return Accepted, nil
 }
 
-// Invite Processor
-type inviteProcessor struct {
-	Number int `mapstructure:"number" structs:"number"`
+// Chat Engagement Processor
+const (
+	chatEngagementPageSize    = 500
+	chatEngagementMaxMessages = 10000
+	chatReactionBatchSize     = 100
+)
 
-	retryAfter  time.Duration
-	communityID string
-	factory     Factory
+type chatEngagementProcessor struct {
+	ChannelID      string `mapstructure:"channel_id" structs:"channel_id"`
+	Number         int    `mapstructure:"number" structs:"number"`
+	CountMessages  bool   `mapstructure:"count_messages" structs:"count_messages"`
+	CountReactions bool   `mapstructure:"count_reactions" structs:"count_reactions"`
+
+	retryAfter time.Duration
+	channelID  int64
+	startTime  time.Time
+	factory    Factory
 }
 
-func newInviteProcessor(
+func newChatEngagementProcessor(
 	ctx context.Context,
 	factory Factory,
 	quest entity.Quest,
 	data map[string]any,
 	needParse bool,
-) (*inviteProcessor, error) {
-	invite := inviteProcessor{}
-	err := mapstructure.Decode(data, &invite)
+) (*chatEngagementProcessor, error) {
+	chatEngagement := chatEngagementProcessor{}
+	err := mapstructure.Decode(data, &chatEngagement)
 	if err != nil {
 		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
 		return nil, errorx.Unknown
 	}
 
+	channelID, err := strconv.ParseInt(chatEngagement.ChannelID, 10, 64)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Invalid channel id: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid channel id")
+	}
+
 	if needParse {
-		if invite.Number <= 0 {
-			return nil, errorx.New(errorx.BadRequest, "Number of invites must be positive")
+		if chatEngagement.Number <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Number of messages or reactions must be positive")
 		}
-	}
 
-	invite.retryAfter = xcontext.Configs(ctx).Quest.InviteReclaimDelay
-	invite.communityID = quest.CommunityID.String
-	invite.factory = factory
+		if !chatEngagement.CountMessages && !chatEngagement.CountReactions {
+			return nil, errorx.New(errorx.BadRequest, "Must count at least messages or reactions")
+		}
 
-	return &invite, nil
+		channel, err := factory.chatChannelRepo.GetByID(ctx, channelID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Not found channel")
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get channel: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if channel.CommunityID != quest.CommunityID.String {
+			return nil, errorx.New(errorx.BadRequest, "Channel doesn't belong to the community")
+		}
+	}
+
+	chatEngagement.retryAfter = xcontext.Configs(ctx).Quest.ChatReclaimDelay
+	chatEngagement.channelID = channelID
+	chatEngagement.startTime = quest.CreatedAt
+	if quest.StartAt.Valid {
+		chatEngagement.startTime = quest.StartAt.Time
+	}
+	chatEngagement.factory = factory
+	return &chatEngagement, nil
 }
 
-func (p inviteProcessor) RetryAfter() time.Duration {
+func (p chatEngagementProcessor) RetryAfter() time.Duration {
+	return p.retryAfter
+}
+
+// GetActionForClaim counts messages and reactions of the user in the channel
+// since the quest started. Reactions are only counted on messages which were
+// sent after the quest started.
+func (p *chatEngagementProcessor) GetActionForClaim(ctx context.Context, submissionData string) (ActionForClaim, error) {
+	requestUserID := xcontext.RequestUserID(ctx)
+	after := numberutil.SnowflakeFromTime(p.startTime)
+
+	count := 0
+	completed := false
+	before := int64(0)
+	for scanned := 0; scanned < chatEngagementMaxMessages && count < p.Number; {
+		messages, err := p.factory.chatMessageRepo.GetListByLastMessage(ctx, repository.LastMessageFilter{
+			ChannelID:  p.channelID,
+			Before:     before,
+			After:      after,
+			Limit:      chatEngagementPageSize,
+			FromBucket: numberutil.BucketFrom(before),
+			ToBucket:   numberutil.BucketFrom(after),
+		})
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot get list messages: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if len(messages) == 0 {
+			completed = true
+			break
+		}
+
+		scanned += len(messages)
+		messageIDs := []int64{}
+		for _, msg := range messages {
+			if p.CountMessages && msg.AuthorID == requestUserID {
+				count++
+			}
+
+			messageIDs = append(messageIDs, msg.ID)
+			if before == 0 || msg.ID < before {
+				before = msg.ID
+			}
+		}
+
+		if p.CountReactions {
+			for i := 0; i < len(messageIDs) && count < p.Number; i += chatReactionBatchSize {
+				end := i + chatReactionBatchSize
+				if end > len(messageIDs) {
+					end = len(messageIDs)
+				}
+
+				reactions, err := p.factory.chatReactionRepo.GetByMessageIDs(ctx, messageIDs[i:end])
+				if err != nil {
+					xcontext.Logger(ctx).Errorf("Cannot get reactions: %v", err)
+					return nil, errorx.Unknown
+				}
+
+				for _, reaction := range reactions {
+					if slices.Contains(reaction.UserIds, requestUserID) {
+						count++
+					}
+				}
+			}
+		}
+
+		if len(messages) < chatEngagementPageSize {
+			completed = true
+			break
+		}
+	}
+
+	// Messages are read from the latest bucket backwards, completed is false if
+	// chatEngagementMaxMessages messages were scanned without reaching the
+	// bucket of the start time.
+	return scannedCountResult(count, p.Number, completed, "messages or reactions")
+}
+
+// Invite Processor
+type inviteProcessor struct {
+	Number int `mapstructure:"number" structs:"number"`
+
+	// MinInviteeQuests is the number of quests an invitee must complete to be
+	// counted. If it is zero, all invitees are counted.
+	MinInviteeQuests int `mapstructure:"min_invitee_quests" structs:"min_invitee_quests"`
+
+	retryAfter  time.Duration
+	communityID string
+	factory     Factory
+}
+
+func newInviteProcessor(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	data map[string]any,
+	needParse bool,
+) (*inviteProcessor, error) {
+	invite := inviteProcessor{}
+	err := mapstructure.Decode(data, &invite)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if invite.Number <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Number of invites must be positive")
+		}
+
+		if invite.MinInviteeQuests < 0 {
+			return nil, errorx.New(errorx.BadRequest, "Minimum quests of invitee must not be negative")
+		}
+	}
+
+	invite.retryAfter = xcontext.Configs(ctx).Quest.InviteReclaimDelay
+	invite.communityID = quest.CommunityID.String
+	invite.factory = factory
+
+	return &invite, nil
+}
+
+func (p inviteProcessor) RetryAfter() time.Duration {
 	return p.retryAfter
 }
 
@@ -796,5 +1412,482 @@ func (p *inviteProcessor) GetActionForClaim(ctx context.Context, submissionData
 			"Not enough number of invites (got %d, but expected %d)", follower.InviteCount, p.Number), nil
 	}
 
+	if p.MinInviteeQuests > 0 {
+		count, err := p.factory.followerRepo.Count(ctx, repository.StatisticFollowerFilter{
+			CommunityID: p.communityID,
+			InvitedBy:   follower.UserID,
+			MinQuests:   uint64(p.MinInviteeQuests),
+		})
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot count invitees: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if count < int64(p.Number) {
+			return Rejected.WithMessage(
+				"Not enough number of invitees completing at least %d quests (got %d, but expected %d)",
+				p.MinInviteeQuests, count, p.Number), nil
+		}
+	}
+
+	return Accepted, nil
+}
+
+// Hold ERC20 Processor
+type holdERC20Processor struct {
+	Chain         string `mapstructure:"chain" structs:"chain"`
+	TokenAddress  string `mapstructure:"token_address" structs:"token_address"`
+	TokenSymbol   string `mapstructure:"token_symbol" structs:"token_symbol"`
+	TokenDecimals int    `mapstructure:"token_decimals" structs:"token_decimals"`
+
+	// Amount is a decimal string, so the required balance can be computed
+	// exactly without float rounding.
+	Amount string `mapstructure:"amount" structs:"amount"`
+
+	retryAfter time.Duration
+	factory    Factory
+}
+
+func newHoldERC20Processor(
+	ctx context.Context,
+	factory Factory,
+	data map[string]any,
+	needParse bool,
+) (*holdERC20Processor, error) {
+	holdERC20 := holdERC20Processor{}
+
+	// The amount was stored as a number before, weak decoding converts it to
+	// a decimal string.
+	err := mapstructure.WeakDecode(data, &holdERC20)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if holdERC20.Chain == "" {
+			return nil, errorx.New(errorx.BadRequest, "Not found chain")
+		}
+
+		if holdERC20.TokenAddress == "" {
+			return nil, errorx.New(errorx.BadRequest, "Not found token")
+		}
+
+		if err = factory.blockchainRepo.Check(ctx, holdERC20.Chain); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Got an unsupported chain %s", holdERC20.Chain)
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot check chain: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		token, err := factory.blockchainRepo.GetToken(ctx, holdERC20.Chain, holdERC20.TokenAddress)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Got an unsupported token %s on chain %s",
+					holdERC20.TokenAddress, holdERC20.Chain)
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get token: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		holdERC20.TokenSymbol = token.Symbol
+		holdERC20.TokenDecimals = token.Decimals
+
+		amount, err := numberutil.ParseDecimal(holdERC20.Amount, holdERC20.TokenDecimals)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Invalid amount: %v", err)
+			return nil, errorx.New(errorx.BadRequest,
+				"Amount must be a decimal with at most %d fractional digits", holdERC20.TokenDecimals)
+		}
+
+		if amount.Sign() <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Amount must be a positive")
+		}
+	}
+
+	holdERC20.retryAfter = xcontext.Configs(ctx).Quest.BlockchainReclaimDelay
+	holdERC20.factory = factory
+	return &holdERC20, nil
+}
+
+func (p holdERC20Processor) RetryAfter() time.Duration {
+	return p.retryAfter
+}
+
+func (p *holdERC20Processor) GetActionForClaim(ctx context.Context, submissionData string) (ActionForClaim, error) {
+	user, err := p.factory.userRepo.GetByID(ctx, xcontext.RequestUserID(ctx))
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get user: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if !user.WalletAddress.Valid || user.WalletAddress.String == "" {
+		return nil, errorx.New(errorx.Unavailable, "User has not connected to wallet")
+	}
+
+	balance, err := p.factory.blockchainCaller.ERC20BalanceOf(
+		ctx, p.Chain, p.TokenAddress, user.WalletAddress.String)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot get balance of %s on chain %s: %v",
+			user.WalletAddress.String, p.Chain, err)
+		return nil, errorx.New(errorx.Unavailable, "Cannot verify your token balance")
+	}
+
+	requiredBalance, err := numberutil.ParseDecimal(p.Amount, p.TokenDecimals)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Invalid amount of hold erc20 quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if balance.Cmp(requiredBalance) < 0 {
+		return Rejected.WithMessage("Not enough %s (got %s, but expected %s)",
+			p.TokenSymbol, numberutil.FormatDecimal(balance, p.TokenDecimals), p.Amount), nil
+	}
+
+	return Accepted, nil
+}
+
+// Hold NFT Processor
+type holdNFTProcessor struct {
+	TokenID   int64  `mapstructure:"token_id" structs:"token_id"`
+	TokenName string `mapstructure:"token_name" structs:"token_name"`
+	Chain     string `mapstructure:"chain" structs:"chain"`
+	Amount    int    `mapstructure:"amount" structs:"amount"`
+
+	retryAfter time.Duration
+	factory    Factory
+}
+
+func newHoldNFTProcessor(
+	ctx context.Context,
+	factory Factory,
+	data map[string]any,
+	needParse bool,
+) (*holdNFTProcessor, error) {
+	holdNFT := holdNFTProcessor{}
+	err := mapstructure.Decode(data, &holdNFT)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if holdNFT.Amount <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Amount must be a positive")
+		}
+
+		nft, err := factory.nftRepo.GetByID(ctx, holdNFT.TokenID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Not found NFT")
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get nft: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		holdNFT.TokenName = nft.Name
+		holdNFT.Chain = nft.Chain
+	}
+
+	holdNFT.retryAfter = xcontext.Configs(ctx).Quest.BlockchainReclaimDelay
+	holdNFT.factory = factory
+	return &holdNFT, nil
+}
+
+func (p holdNFTProcessor) RetryAfter() time.Duration {
+	return p.retryAfter
+}
+
+func (p *holdNFTProcessor) GetActionForClaim(ctx context.Context, submissionData string) (ActionForClaim, error) {
+	balance, err := p.factory.getNFTBalance(ctx, xcontext.RequestUserID(ctx), p.TokenID, p.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if balance.Cmp(big.NewInt(int64(p.Amount))) < 0 {
+		return Rejected.WithMessage(
+			"Not enough %s (got %s, but expected %d)", p.TokenName, balance.String(), p.Amount), nil
+	}
+
+	return Accepted, nil
+}
+
+// Contract Interaction Processor
+type contractInteractionProcessor struct {
+	Chain           string `mapstructure:"chain" structs:"chain"`
+	ContractAddress string `mapstructure:"contract_address" structs:"contract_address"`
+	Method          string `mapstructure:"method" structs:"method"`
+	MethodSelector  string `mapstructure:"method_selector" structs:"method_selector"`
+	Event           string `mapstructure:"event" structs:"event"`
+	EventTopic      string `mapstructure:"event_topic" structs:"event_topic"`
+
+	retryAfter time.Duration
+	questID    string
+	factory    Factory
+}
+
+func newContractInteractionProcessor(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	data map[string]any,
+	needParse bool,
+) (*contractInteractionProcessor, error) {
+	contractInteraction := contractInteractionProcessor{}
+	err := mapstructure.Decode(data, &contractInteraction)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if contractInteraction.Chain == "" {
+			return nil, errorx.New(errorx.BadRequest, "Not found chain")
+		}
+
+		if err = factory.blockchainRepo.Check(ctx, contractInteraction.Chain); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound,
+					"Got an unsupported chain %s", contractInteraction.Chain)
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot check chain: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if !ethcommon.IsHexAddress(contractInteraction.ContractAddress) {
+			return nil, errorx.New(errorx.BadRequest, "Invalid contract address")
+		}
+
+		contractInteraction.MethodSelector = ""
+		if contractInteraction.Method != "" {
+			contractInteraction.MethodSelector, err = parseHashedSignature(contractInteraction.Method, 4)
+			if err != nil {
+				xcontext.Logger(ctx).Debugf("Invalid method: %v", err)
+				return nil, errorx.New(errorx.BadRequest, "Invalid method, it must be a selector or signature")
+			}
+		}
+
+		contractInteraction.EventTopic = ""
+		if contractInteraction.Event != "" {
+			contractInteraction.EventTopic, err = parseHashedSignature(contractInteraction.Event, 32)
+			if err != nil {
+				xcontext.Logger(ctx).Debugf("Invalid event: %v", err)
+				return nil, errorx.New(errorx.BadRequest, "Invalid event, it must be a topic or signature")
+			}
+		}
+	}
+
+	contractInteraction.retryAfter = xcontext.Configs(ctx).Quest.BlockchainReclaimDelay
+	contractInteraction.questID = quest.ID
+	contractInteraction.factory = factory
+	return &contractInteraction, nil
+}
+
+func (p contractInteractionProcessor) RetryAfter() time.Duration {
+	return p.retryAfter
+}
+
+// NormalizeSubmissionData encodes the transaction hash in lowercase with the 0x
+// prefix, so the same transaction cannot be used twice by changing its case.
+func (p contractInteractionProcessor) NormalizeSubmissionData(submissionData string) string {
+	txHash, err := hexutil.Decode(strings.TrimSpace(submissionData))
+	if err != nil || len(txHash) != ethcommon.HashLength {
+		return submissionData
+	}
+
+	return hexutil.Encode(txHash)
+}
+
+func (p *contractInteractionProcessor) GetActionForClaim(
+	ctx context.Context, submissionData string,
+) (ActionForClaim, error) {
+	submissionData = p.NormalizeSubmissionData(submissionData)
+	txHash, err := hexutil.Decode(submissionData)
+	if err != nil || len(txHash) != ethcommon.HashLength {
+		xcontext.Logger(ctx).Debugf("Invalid transaction hash: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid transaction hash")
+	}
+
+	user, err := p.factory.userRepo.GetByID(ctx, xcontext.RequestUserID(ctx))
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get user: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if !user.WalletAddress.Valid || user.WalletAddress.String == "" {
+		return nil, errorx.New(errorx.Unavailable, "User has not connected to wallet")
+	}
+
+	_, err = p.factory.claimedQuestRepo.GetLast(ctx, repository.GetLastClaimedQuestFilter{
+		QuestID:        p.questID,
+		SubmissionData: submissionData,
+		Status:         []entity.ClaimedQuestStatus{entity.Accepted, entity.AutoAccepted},
+	})
+	if err == nil {
+		return Rejected.WithMessage("This transaction has been used to claim the quest"), nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		xcontext.Logger(ctx).Errorf("Cannot get claimed quest: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	receipt, err := p.factory.blockchainCaller.TransactionReceipt(ctx, p.Chain, submissionData)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Cannot get transaction receipt: %v", err)
+		return Rejected.WithMessage("Not found the transaction on chain %s", p.Chain), nil
+	}
+
+	if !receipt.Success {
+		return Rejected.WithMessage("The transaction is failed"), nil
+	}
+
+	if !isSameAddress(receipt.From, user.WalletAddress.String) {
+		return Rejected.WithMessage("The transaction is not sent from your wallet"), nil
+	}
+
+	if !isSameAddress(receipt.To, p.ContractAddress) {
+		return Rejected.WithMessage("The transaction doesn't interact with the required contract"), nil
+	}
+
+	if p.MethodSelector != "" {
+		if len(receipt.Input) < 4 || hexutil.Encode(receipt.Input[:4]) != p.MethodSelector {
+			return Rejected.WithMessage("The transaction doesn't call the method %s", p.Method), nil
+		}
+	}
+
+	if p.EventTopic != "" {
+		emitted := false
+		for _, log := range receipt.Logs {
+			// Other contracts called by the required contract may emit an event
+			// with the same signature, only count the one of required contract.
+			if !isSameAddress(log.Address, p.ContractAddress) {
+				continue
+			}
+
+			if len(log.Topics) > 0 && strings.EqualFold(log.Topics[0], p.EventTopic) {
+				emitted = true
+				break
+			}
+		}
+
+		if !emitted {
+			return Rejected.WithMessage("The transaction doesn't emit the event %s", p.Event), nil
+		}
+	}
+
+	return Accepted, nil
+}
+
+// Sign Message Processor
+type signMessageProcessor struct {
+	Message string `mapstructure:"message" structs:"message"`
+
+	questID string
+	factory Factory
+}
+
+func newSignMessageProcessor(
+	ctx context.Context,
+	factory Factory,
+	quest entity.Quest,
+	data map[string]any,
+	needParse bool,
+) (*signMessageProcessor, error) {
+	signMessage := signMessageProcessor{}
+	err := mapstructure.Decode(data, &signMessage)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if signMessage.Message == "" {
+			return nil, errorx.New(errorx.BadRequest, "Not found message")
+		}
+
+		messageOfUser1, err := signMessage.message("user1", quest.ID)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Invalid message template: %v", err)
+			return nil, errorx.New(errorx.BadRequest, "Invalid message template")
+		}
+
+		// A message which is the same for every user allows a signature to be
+		// reused by anyone, so the user id must be a part of the message.
+		messageOfUser2, err := signMessage.message("user2", quest.ID)
+		if err != nil {
+			xcontext.Logger(ctx).Debugf("Invalid message template: %v", err)
+			return nil, errorx.New(errorx.BadRequest, "Invalid message template")
+		}
+
+		if messageOfUser1 == messageOfUser2 {
+			return nil, errorx.New(errorx.BadRequest, "The message template must include {{.user_id}}")
+		}
+	}
+
+	signMessage.questID = quest.ID
+	signMessage.factory = factory
+	return &signMessage, nil
+}
+
+// message returns the message which user must sign. The template must include
+// {{.user_id}} and can include {{.quest_id}} to prevent signatures from being
+// reused by other users or in other quests.
+func (p signMessageProcessor) message(userID, questID string) (string, error) {
+	return common.ExecuteTemplate(p.Message, map[string]any{
+		"user_id":  userID,
+		"quest_id": questID,
+	})
+}
+
+func (p signMessageProcessor) RetryAfter() time.Duration {
+	return 0
+}
+
+func (p *signMessageProcessor) GetActionForClaim(ctx context.Context, submissionData string) (ActionForClaim, error) {
+	// If user provides a custom wallet address to receive rewards, the
+	// signature must prove the ownership of that wallet. Otherwise, the
+	// signature must be signed by the linked wallet.
+	expectedAddress := claimWalletAddress(ctx)
+	if expectedAddress == "" {
+		user, err := p.factory.userRepo.GetByID(ctx, xcontext.RequestUserID(ctx))
+		if err != nil {
+			xcontext.Logger(ctx).Errorf("Cannot get user: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if !user.WalletAddress.Valid || user.WalletAddress.String == "" {
+			return nil, errorx.New(errorx.Unavailable,
+				"User must connect to wallet or use a custom wallet address to claim this quest")
+		}
+
+		expectedAddress = user.WalletAddress.String
+	}
+
+	if !ethcommon.IsHexAddress(expectedAddress) {
+		return nil, errorx.New(errorx.BadRequest, "Invalid wallet address")
+	}
+
+	message, err := p.message(xcontext.RequestUserID(ctx), p.questID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot execute message template: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	signer, err := ethutil.RecoverTextSigner([]byte(message), submissionData)
+	if err != nil {
+		xcontext.Logger(ctx).Debugf("Cannot recover signature: %v", err)
+		return nil, errorx.New(errorx.BadRequest, "Invalid signature")
+	}
+
+	if !isSameAddress(signer.Hex(), expectedAddress) {
+		return Rejected.WithMessage("The message is not signed by wallet %s", expectedAddress), nil
+	}
+
 	return Accepted, nil
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
@@ -305,10 +307,20 @@ func newCoinReward(
 }
 
 func (r *CoinReward) Give(ctx context.Context) error {
+	amount := r.Amount
+	if r.claimedQuest != nil && r.claimedQuest.RewardScale.Valid {
+		amount *= r.claimedQuest.RewardScale.Float64
+	}
+
+	if amount <= 0 {
+		// The reviewer decided to skip this reward.
+		return nil
+	}
+
 	payreward := &entity.PayReward{
 		Base:          entity.Base{ID: uuid.NewString()},
 		TokenID:       sql.NullString{Valid: true, String: r.TokenID},
-		Amount:        r.Amount,
+		Amount:        amount,
 		ToUserID:      r.getUserID(),
 		TransactionID: sql.NullString{Valid: false}, // pending for processing at blockchain service.
 	}
@@ -406,3 +418,286 @@ func (r *NonFungibleTokenReward) Give(ctx context.Context) error {
 
 	return r.completeAndCreatePayReward(ctx, payreward)
 }
+
+// Badge Reward
+type BadgeReward struct {
+	Name        string `mapstructure:"name" structs:"name"`
+	Level       int    `mapstructure:"level" structs:"level"`
+	Description string `mapstructure:"description" structs:"description"`
+	IconURL     string `mapstructure:"icon_url" structs:"icon_url"`
+
+	communityID string
+	commonReward
+}
+
+func newBadgeReward(
+	ctx context.Context,
+	communityID string,
+	factory Factory,
+	data map[string]any,
+	needParse bool,
+) (*BadgeReward, error) {
+	reward := BadgeReward{communityID: communityID}
+	err := mapstructure.Decode(data, &reward)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if reward.Name == "" {
+			return nil, errorx.New(errorx.BadRequest, "Not found badge name")
+		}
+
+		if reward.Level == 0 {
+			reward.Level = 1
+		}
+
+		if reward.Level < 0 {
+			return nil, errorx.New(errorx.BadRequest, "Require a positive level")
+		}
+
+		if reward.IconURL != "" {
+			if _, err := url.ParseRequestURI(reward.IconURL); err != nil {
+				xcontext.Logger(ctx).Debugf("Invalid icon url: %v", err)
+				return nil, errorx.New(errorx.BadRequest, "Invalid icon url")
+			}
+		}
+
+		if err := factory.badgeManager.CheckCommunityBadge(reward.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	reward.factory = factory
+	return &reward, nil
+}
+
+func (r *BadgeReward) Give(ctx context.Context) error {
+	userID := r.getUserID()
+	if userID == "" {
+		xcontext.Logger(ctx).Errorf("Not found user to give badge")
+		return errorx.Unknown
+	}
+
+	return r.factory.badgeManager.GiveCommunityBadge(ctx, userID, r.communityID, entity.Badge{
+		Name:        r.Name,
+		Level:       r.Level,
+		Description: r.Description,
+		IconURL:     r.IconURL,
+	})
+}
+
+// Role Reward
+type RoleReward struct {
+	RoleID   string `mapstructure:"role_id" structs:"role_id"`
+	RoleName string `mapstructure:"role_name" structs:"role_name"`
+
+	communityID string
+	commonReward
+}
+
+func newRoleReward(
+	ctx context.Context,
+	communityID string,
+	factory Factory,
+	data map[string]any,
+	needParse bool,
+) (*RoleReward, error) {
+	reward := RoleReward{communityID: communityID}
+	err := mapstructure.Decode(data, &reward)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		role, err := factory.roleRepo.GetByID(ctx, reward.RoleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Not found role")
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get role: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if !role.CommunityID.Valid || role.CommunityID.String != communityID {
+			return nil, errorx.New(errorx.BadRequest, "Role doesn't belong to the community")
+		}
+
+		// Do not allow to escalate privilege of users through quests.
+		if role.Permissions != 0 {
+			return nil, errorx.New(errorx.Unavailable, "Only roles without permissions can be given as reward")
+		}
+
+		reward.RoleName = role.Name
+	}
+
+	reward.factory = factory
+	return &reward, nil
+}
+
+func (r *RoleReward) Give(ctx context.Context) error {
+	userID := r.getUserID()
+	if userID == "" {
+		xcontext.Logger(ctx).Errorf("Not found user to give role")
+		return errorx.Unknown
+	}
+
+	followerRole, err := r.getFollowerRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if followerRole != nil {
+		return nil
+	}
+
+	followerRole = &entity.FollowerRole{
+		UserID:      userID,
+		CommunityID: r.communityID,
+		RoleID:      r.RoleID,
+	}
+
+	if r.claimedQuest != nil {
+		followerRole.GrantedByClaimedQuestID = sql.NullString{Valid: true, String: r.claimedQuest.ID}
+	}
+
+	err = r.factory.followerRoleRepo.Create(ctx, followerRole)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot assign role to user: %v", err)
+		return errorx.Unknown
+	}
+
+	return nil
+}
+
+func (r *RoleReward) Revoke(ctx context.Context) error {
+	userID := r.getUserID()
+	if userID == "" {
+		xcontext.Logger(ctx).Errorf("Not found user to revoke role")
+		return errorx.Unknown
+	}
+
+	followerRole, err := r.getFollowerRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Only revoke the role if it was granted by this claimed quest. Otherwise,
+	// the user had it before or got it by other ways.
+	if followerRole == nil || r.claimedQuest == nil ||
+		followerRole.GrantedByClaimedQuestID.String != r.claimedQuest.ID {
+		return nil
+	}
+
+	if err := r.factory.followerRoleRepo.Delete(ctx, userID, r.communityID, r.RoleID); err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot revoke role of user: %v", err)
+		return errorx.Unknown
+	}
+
+	return nil
+}
+
+func (r *RoleReward) getFollowerRole(ctx context.Context, userID string) (*entity.FollowerRole, error) {
+	followerRoles, err := r.factory.followerRoleRepo.Get(ctx, userID, r.communityID)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot get follower roles: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	for _, followerRole := range followerRoles {
+		if followerRole.RoleID == r.RoleID {
+			return &followerRole, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Lottery Ticket Reward
+type LotteryTicketReward struct {
+	EventID string `mapstructure:"event_id" structs:"event_id"`
+	Tickets int    `mapstructure:"tickets" structs:"tickets"`
+
+	commonReward
+}
+
+func newLotteryTicketReward(
+	ctx context.Context,
+	communityID string,
+	factory Factory,
+	data map[string]any,
+	needParse bool,
+) (*LotteryTicketReward, error) {
+	reward := LotteryTicketReward{}
+	err := mapstructure.Decode(data, &reward)
+	if err != nil {
+		xcontext.Logger(ctx).Warnf("Cannot decode map to struct: %v", err)
+		return nil, errorx.Unknown
+	}
+
+	if needParse {
+		if reward.Tickets <= 0 {
+			return nil, errorx.New(errorx.BadRequest, "Number of tickets must be a positive")
+		}
+
+		event, err := factory.lotteryRepo.GetEventByID(ctx, reward.EventID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorx.New(errorx.NotFound, "Not found lottery event")
+			}
+
+			xcontext.Logger(ctx).Errorf("Cannot get lottery event: %v", err)
+			return nil, errorx.Unknown
+		}
+
+		if event.CommunityID != communityID {
+			return nil, errorx.New(errorx.BadRequest, "Lottery event doesn't belong to the community")
+		}
+
+		if !event.EndTime.After(time.Now()) {
+			return nil, errorx.New(errorx.Unavailable, "The lottery event has ended")
+		}
+	}
+
+	reward.factory = factory
+	return &reward, nil
+}
+
+func (r *LotteryTicketReward) Give(ctx context.Context) error {
+	userID := r.getUserID()
+	if userID == "" {
+		xcontext.Logger(ctx).Errorf("Not found user to give lottery tickets")
+		return errorx.Unknown
+	}
+
+	// The free tickets still consume the tickets of event when they are used,
+	// so no need to check the limit of event here.
+	err := r.factory.lotteryRepo.IncreaseFreeTickets(ctx, r.EventID, userID, r.Tickets)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot give free lottery tickets: %v", err)
+		return errorx.Unknown
+	}
+
+	return nil
+}
+
+func (r *LotteryTicketReward) Revoke(ctx context.Context) error {
+	userID := r.getUserID()
+	if userID == "" {
+		xcontext.Logger(ctx).Errorf("Not found user to revoke lottery tickets")
+		return errorx.Unknown
+	}
+
+	// Only the unused tickets can be taken back, the tickets which user spent
+	// to spin are kept.
+	err := r.factory.lotteryRepo.DecreaseFreeTickets(ctx, r.EventID, userID, r.Tickets)
+	if err != nil {
+		xcontext.Logger(ctx).Errorf("Cannot revoke free lottery tickets: %v", err)
+		return errorx.Unknown
+	}
+
+	return nil
+}
@@ -4,6 +4,10 @@ import (
 	"errors"
 	"net/url"
 	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type tweet struct {
@@ -77,3 +81,32 @@ func parseInviteTelegramURL(rawURL string) (string, error) {
 
 	return strings.TrimLeft(u.Path, "/"), nil
 }
+
+func isSameAddress(a, b string) bool {
+	return ethcommon.HexToAddress(a) == ethcommon.HexToAddress(b)
+}
+
+// parseHashedSignature accepts either a hex string with the expected length in
+// bytes or a human-readable signature (e.g. "transfer(address,uint256)"), then
+// returns the hex string of the first n bytes of keccak256 hash.
+func parseHashedSignature(s string, n int) (string, error) {
+	if strings.HasPrefix(s, "0x") {
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return "", err
+		}
+
+		if len(b) != n {
+			return "", errors.New("invalid length")
+		}
+
+		return hexutil.Encode(b), nil
+	}
+
+	if !strings.Contains(s, "(") || !strings.HasSuffix(s, ")") {
+		return "", errors.New("invalid signature")
+	}
+
+	signature := strings.ReplaceAll(s, " ", "")
+	return hexutil.Encode(crypto.Keccak256([]byte(signature))[:n]), nil
+}
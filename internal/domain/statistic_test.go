@@ -35,6 +35,8 @@ func Test_statisticDomain_GetLeaderboard(t *testing.T) {
 		repository.NewUserRepository(testutil.RedisClient(ctx)),
 		repository.NewCommunityRepository(&testutil.MockSearchCaller{}, testutil.RedisClient(ctx)),
 		repository.NewCategoryRepository(),
+		repository.NewSurveyAnswerRepository(),
+		repository.NewOAuth2Repository(),
 		badge.NewManager(repository.NewBadgeRepository(),
 			repository.NewBadgeDetailRepository(),
 			&testutil.MockBadge{NameValue: badge.SharpScoutBadgeName},
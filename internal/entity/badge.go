@@ -1,10 +1,23 @@
 package entity
 
+import "database/sql"
+
 type Badge struct {
 	Base
-	Name        string `gorm:"index:idx_badges_name_level,unique"`
-	Level       int    `gorm:"index:idx_badges_name_level,unique"`
+	Name        string `gorm:"index:idx_badges_community_key_name_level,unique,priority:2"`
+	Level       int    `gorm:"index:idx_badges_community_key_name_level,unique,priority:3"`
 	Description string
 	Value       int
 	IconURL     string
+
+	// CommunityID is only valid for badges defined by a community, these
+	// badges are given as quest rewards instead of being scanned. Communities
+	// can define badges with the same name and level independently.
+	CommunityID sql.NullString
+	Community   Community `gorm:"foreignKey:CommunityID"`
+
+	// CommunityKey is generated by database from CommunityID. NULL values are
+	// distinct in unique indexes, so this column is used instead of
+	// CommunityID to keep system badges unique.
+	CommunityKey string `gorm:"->;type:varchar(256) GENERATED ALWAYS AS (COALESCE(community_id, '')) STORED;index:idx_badges_community_key_name_level,unique,priority:1"`
 }
@@ -14,6 +14,7 @@ var (
 	Rejected     = enum.New(ClaimedQuestStatus("rejected"))
 	AutoRejected = enum.New(ClaimedQuestStatus("auto_rejected"))
 	AutoAccepted = enum.New(ClaimedQuestStatus("auto_accepted"))
+	Appealed     = enum.New(ClaimedQuestStatus("appealed"))
 )
 
 type ClaimedQuest struct {
@@ -31,6 +32,37 @@ type ClaimedQuest struct {
 	ReviewedAt     sql.NullTime
 	Comment        string
 
+	// Set by the reviewer to grant a different amount of points than the
+	// quest's points, or to scale coin rewards (0 means skip).
+	OverriddenPoints sql.NullInt64
+	RewardScale      sql.NullFloat64
+
 	// Only for claiming quests with coin reward.
 	WalletAddress string
 }
+
+// This struct is not a table in database. No need a migration if modifying it.
+type ReviewerStatistic struct {
+	ReviewerID string
+	Accepted   uint64
+	Rejected   uint64
+	Unapproved uint64
+}
+
+// ClaimedQuestHistory records a change of claimed quest, such as claiming,
+// reviewing, or appealing.
+type ClaimedQuestHistory struct {
+	Base
+
+	ClaimedQuestID string
+	ClaimedQuest   ClaimedQuest `gorm:"foreignKey:ClaimedQuestID"`
+
+	// ActorID is the user who made this change, it is the claimer or a
+	// reviewer.
+	ActorID string
+	Actor   User `gorm:"foreignKey:ActorID"`
+
+	Status         ClaimedQuestStatus
+	SubmissionData string
+	Comment        string
+}
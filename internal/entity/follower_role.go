@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"database/sql"
 	"time"
 )
 
@@ -15,4 +16,8 @@ type FollowerRole struct {
 
 	RoleID string `gorm:"primaryKey"`
 	Role   Role   `gorm:"foreignKey:RoleID"`
+
+	// GrantedByClaimedQuestID is the claimed quest which gave this role to the
+	// user as a reward. It is null if the role was assigned by other ways.
+	GrantedByClaimedQuestID sql.NullString
 }
@@ -39,3 +39,15 @@ type LotteryWinner struct {
 
 	IsClaimed bool
 }
+
+// LotteryFreeTicket is the number of tickets which user can use to spin without
+// spending points. These tickets are given as quest rewards.
+type LotteryFreeTicket struct {
+	LotteryEventID string       `gorm:"primaryKey"`
+	LotteryEvent   LotteryEvent `gorm:"foreignKey:LotteryEventID"`
+
+	UserID string `gorm:"primaryKey"`
+	User   User   `gorm:"foreignKey:UserID"`
+
+	Tickets int
+}
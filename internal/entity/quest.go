@@ -15,6 +15,7 @@ var (
 	QuestVisitLink = enum.New(QuestType("visit_link"))
 	QuestText      = enum.New(QuestType("text"))
 	QuestQuiz      = enum.New(QuestType("quiz"))
+	QuestSurvey    = enum.New(QuestType("survey"))
 	QuestEmpty     = enum.New(QuestType("empty"))
 	QuestInvite    = enum.New(QuestType("invite"))
 
@@ -25,11 +26,21 @@ var (
 	QuestTwitterJoinSpace = enum.New(QuestType("twitter_join_space"))
 
 	// Discord quests
-	QuestJoinDiscord   = enum.New(QuestType("join_discord"))
-	QuestInviteDiscord = enum.New(QuestType("invite_discord"))
+	QuestJoinDiscord    = enum.New(QuestType("join_discord"))
+	QuestInviteDiscord  = enum.New(QuestType("invite_discord"))
+	QuestDiscordMessage = enum.New(QuestType("discord_message"))
 
 	// Telegram quests
 	QuestJoinTelegram = enum.New(QuestType("join_telegram"))
+
+	// Chat quests
+	QuestChatEngagement = enum.New(QuestType("chat_engagement"))
+
+	// Blockchain quests
+	QuestHoldERC20           = enum.New(QuestType("hold_erc20"))
+	QuestHoldNFT             = enum.New(QuestType("hold_nft"))
+	QuestContractInteraction = enum.New(QuestType("contract_interaction"))
+	QuestSignMessage         = enum.New(QuestType("sign_message"))
 )
 
 type RecurrenceType string
@@ -62,6 +73,9 @@ var (
 	DiscordRoleReward = enum.New(RewardType("discord_role"))
 	CoinReward        = enum.New(RewardType("coin"))
 	NFTReward         = enum.New(RewardType("nft"))
+	BadgeReward       = enum.New(RewardType("badge"))
+	RoleReward        = enum.New(RewardType("role"))
+	LotteryReward     = enum.New(RewardType("lottery_ticket"))
 )
 
 type ConditionType string
@@ -70,6 +84,16 @@ var (
 	QuestCondition   = enum.New(ConditionType("quest"))
 	DateCondition    = enum.New(ConditionType("date"))
 	DiscordCondition = enum.New(ConditionType("discord"))
+	NFTCondition     = enum.New(ConditionType("nft"))
+	GroupCondition   = enum.New(ConditionType("group"))
+	BadgeCondition   = enum.New(ConditionType("badge"))
+	RoleCondition    = enum.New(ConditionType("role"))
+
+	// Follower conditions
+	PointsCondition     = enum.New(ConditionType("points"))
+	QuestCountCondition = enum.New(ConditionType("quest_count"))
+	ChatLevelCondition  = enum.New(ConditionType("chat_level"))
+	StreakCondition     = enum.New(ConditionType("streak"))
 )
 
 type Reward struct {
@@ -103,4 +127,30 @@ type Quest struct {
 	ConditionOp    ConditionOpType
 	Conditions     Array[Condition]
 	IsHighlight    bool
+
+	// MaxClaims limits the total number of accepted claims of this quest, it
+	// is unlimited if the value is zero. ClaimedCount is the current number of
+	// accepted claims.
+	MaxClaims    int
+	ClaimedCount int
+
+	// MaxClaimsPerUser limits the number of claims of a user for recurring
+	// quests, it is unlimited if the value is zero.
+	MaxClaimsPerUser int
+
+	// StartAt and EndAt define the time window which the quest can be claimed.
+	// The quest will be automatically activated at StartAt and archived at
+	// EndAt.
+	StartAt sql.NullTime
+	EndAt   sql.NullTime
+
+	// ActivatedAt is the first time the quest became active. A scheduled quest
+	// is only activated automatically if it has never been activated before,
+	// so a quest which was moved back to draft by the admin is kept as is.
+	ActivatedAt sql.NullTime
+
+	// If RaffleWinners is positive, coin and NFT rewards are not paid per
+	// claim. Instead, they are only paid to RaffleWinners claimers who are
+	// randomly drawn at EndAt.
+	RaffleWinners int
 }
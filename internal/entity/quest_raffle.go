@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// QuestRaffle records the draw of a raffle quest. The seed is kept for auditing,
+// the draw can be reproduced by shuffling all accepted claimed quests (ordered
+// by their creation time, then id) with this seed. Winners are picked in the
+// shuffled order, a participant who cannot receive the rewards is replaced by
+// the next one.
+type QuestRaffle struct {
+	QuestID string `gorm:"primaryKey"`
+	Quest   Quest  `gorm:"foreignKey:QuestID"`
+
+	Seed         int64
+	Participants int
+	Winners      int
+	DrawnAt      time.Time
+
+	// The claimed quests and users which actually received the rewards.
+	WinnerClaimedQuestIDs Array[string]
+	WinnerUserIDs         Array[string]
+}
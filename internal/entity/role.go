@@ -40,6 +40,8 @@ var RBAC = map[string]PermissionFlag{
 	"/updateQuestCategory":     MANAGE_QUEST,
 	"/updateQuestPosition":     MANAGE_QUEST,
 	"/deleteQuest":             MANAGE_QUEST,
+	"/exportQuests":            MANAGE_QUEST,
+	"/importQuests":            MANAGE_QUEST,
 	"/createCategory":          MANAGE_QUEST,
 	"/updateCategory":          MANAGE_QUEST,
 	"/deleteCategory":          MANAGE_QUEST,
@@ -48,7 +50,11 @@ var RBAC = map[string]PermissionFlag{
 	"/getClaimedQuests":        REVIEW_CLAIMED_QUEST,
 	"/review":                  REVIEW_CLAIMED_QUEST,
 	"/reviewAll":               REVIEW_CLAIMED_QUEST,
+	"/reviewByFile":            REVIEW_CLAIMED_QUEST,
+	"/leaseClaimedQuests":      REVIEW_CLAIMED_QUEST,
+	"/exportClaimedQuests":     REVIEW_CLAIMED_QUEST,
 	"/givePoint":               REVIEW_CLAIMED_QUEST,
+	"/getSurveyResult":         REVIEW_CLAIMED_QUEST,
 	"/createChannel":           MANAGE_CHANNEL,
 	"/deleteChannel":           MANAGE_CHANNEL,
 	"/updateChannel":           MANAGE_CHANNEL,
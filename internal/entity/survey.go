@@ -0,0 +1,33 @@
+package entity
+
+import "github.com/questx-lab/backend/pkg/enum"
+
+type SurveyQuestionType string
+
+var (
+	SurveySingleChoice   = enum.New(SurveyQuestionType("single_choice"))
+	SurveyMultipleChoice = enum.New(SurveyQuestionType("multiple_choice"))
+	SurveyRating         = enum.New(SurveyQuestionType("rating"))
+	SurveyText           = enum.New(SurveyQuestionType("text"))
+)
+
+// SurveyAnswer is an answer of a question in survey quest. A multiple choice
+// question may have many answers with different Position.
+type SurveyAnswer struct {
+	ClaimedQuestID string       `gorm:"primaryKey"`
+	ClaimedQuest   ClaimedQuest `gorm:"foreignKey:ClaimedQuestID"`
+
+	Question int `gorm:"primaryKey"`
+	Position int `gorm:"primaryKey"`
+
+	QuestID string `gorm:"index"`
+	Quest   Quest  `gorm:"foreignKey:QuestID"`
+
+	Answer string `gorm:"type:text"`
+}
+
+type SurveyAnswerStatistic struct {
+	Question int
+	Answer   string
+	Count    int64
+}
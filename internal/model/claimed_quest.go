@@ -24,6 +24,24 @@ type GetClaimedQuestRequest struct {
 
 type GetClaimedQuestResponse ClaimedQuest
 
+type AppealClaimedQuestRequest struct {
+	ID             string `json:"id"`
+	Message        string `json:"message"`
+	SubmissionData string `json:"submission_data"`
+}
+
+type AppealClaimedQuestResponse struct{}
+
+type UpdateClaimedQuestRequest struct {
+	ID             string `json:"id"`
+	SubmissionData string `json:"submission_data"`
+}
+
+type UpdateClaimedQuestResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
 type GetListClaimedQuestRequest struct {
 	CommunityHandle string `json:"community_handle"`
 
@@ -46,6 +64,11 @@ type ReviewRequest struct {
 	Action  string   `json:"action"`
 	Comment string   `json:"comment"`
 	IDs     []string `json:"ids"`
+
+	// Optional, only for accepting. Points replaces the quest's points and
+	// RewardScale (from 0 to 1) scales coin rewards, 0 means skip them.
+	Points      *uint64  `json:"points"`
+	RewardScale *float64 `json:"reward_scale"`
 }
 
 type ReviewResponse struct{}
@@ -66,6 +89,25 @@ type ReviewAllResponse struct {
 	Quantity int `json:"quantity"`
 }
 
+type ReviewByFileRequest struct {
+	// Community handle and the decision file are included in form-data. Each
+	// row of the csv file is id, action, comment and optional points.
+}
+
+type ReviewByFileResult struct {
+	Row     int    `json:"row"`
+	ID      string `json:"id"`
+	Action  string `json:"action"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type ReviewByFileResponse struct {
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []ReviewByFileResult `json:"results"`
+}
+
 type GivePointRequest struct {
 	CommunityHandle string `json:"community_handle"`
 	UserID          string `json:"user_id"`
@@ -73,3 +115,70 @@ type GivePointRequest struct {
 }
 
 type GivePointResponse struct{}
+
+type GetSurveyResultRequest struct {
+	QuestID string `json:"quest_id"`
+
+	// Limit is the maximum number of answers returned for each text question.
+	Limit int `json:"limit"`
+}
+
+type GetSurveyResultResponse struct {
+	Questions []SurveyQuestionResult `json:"questions"`
+}
+
+type LeaseClaimedQuestsRequest struct {
+	CommunityHandle string   `json:"community_handle"`
+	QuestIDs        []string `json:"quest_ids"`
+	Limit           int      `json:"limit"`
+}
+
+type LeaseClaimedQuestsResponse struct {
+	ClaimedQuests []ClaimedQuest `json:"claimed_quests"`
+	ExpiredAt     string         `json:"expired_at"`
+}
+
+type ReleaseClaimedQuestsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type ReleaseClaimedQuestsResponse struct{}
+
+type GetReviewerStatisticRequest struct {
+	CommunityHandle string `json:"community_handle"`
+	Period          string `json:"period"`
+}
+
+type GetReviewerStatisticResponse struct {
+	Reviewers []ReviewerStatistic `json:"reviewers"`
+}
+
+type ExportClaimedQuestsRequest struct {
+	CommunityHandle string `json:"community_handle"`
+
+	QuestID    string `json:"quest_id"`
+	UserID     string `json:"user_id"`
+	Recurrence string `json:"recurrence"`
+	Status     string `json:"status"`
+
+	// Format is csv (default) or ndjson.
+	Format string `json:"format"`
+}
+
+type ExportedClaimedQuest struct {
+	ID             string            `json:"id"`
+	QuestID        string            `json:"quest_id"`
+	QuestTitle     string            `json:"quest_title"`
+	UserID         string            `json:"user_id"`
+	Username       string            `json:"username"`
+	OAuth2         map[string]string `json:"oauth2"`
+	WalletAddress  string            `json:"wallet_address"`
+	SubmissionData string            `json:"submission_data"`
+	Status         string            `json:"status"`
+	ReviewerID     string            `json:"reviewer_id"`
+	ReviewerName   string            `json:"reviewer_name"`
+	Comment        string            `json:"comment"`
+	ReviewedAt     string            `json:"reviewed_at"`
+	CreatedAt      string            `json:"created_at"`
+	UpdatedAt      string            `json:"updated_at"`
+}
@@ -208,24 +208,49 @@ func ConvertQuest(quest *entity.Quest, community Community, category Category) Q
 		category = Category{ID: quest.CategoryID.String}
 	}
 
+	// The remaining slots are null if the quest is unlimited.
+	var remainingSlots *int
+	if quest.MaxClaims > 0 {
+		remainingSlots = new(int)
+		if quest.MaxClaims > quest.ClaimedCount {
+			*remainingSlots = quest.MaxClaims - quest.ClaimedCount
+		}
+	}
+
+	startAt := ""
+	if quest.StartAt.Valid {
+		startAt = quest.StartAt.Time.Format(DefaultTimeLayout)
+	}
+
+	endAt := ""
+	if quest.EndAt.Valid {
+		endAt = quest.EndAt.Time.Format(DefaultTimeLayout)
+	}
+
 	return Quest{
-		ID:             quest.ID,
-		Community:      community,
-		Type:           string(quest.Type),
-		Status:         string(quest.Status),
-		Title:          quest.Title,
-		Description:    string(quest.Description),
-		Category:       category,
-		Recurrence:     string(quest.Recurrence),
-		ValidationData: quest.ValidationData,
-		Points:         quest.Points,
-		Rewards:        ConvertRewards(quest.Rewards),
-		ConditionOp:    string(quest.ConditionOp),
-		Conditions:     ConvertConditions(quest.Conditions),
-		CreatedAt:      quest.CreatedAt.Format(DefaultTimeLayout),
-		UpdatedAt:      quest.UpdatedAt.Format(DefaultTimeLayout),
-		IsHighlight:    quest.IsHighlight,
-		Position:       quest.Position,
+		ID:               quest.ID,
+		Community:        community,
+		Type:             string(quest.Type),
+		Status:           string(quest.Status),
+		Title:            quest.Title,
+		Description:      string(quest.Description),
+		Category:         category,
+		Recurrence:       string(quest.Recurrence),
+		ValidationData:   quest.ValidationData,
+		Points:           quest.Points,
+		Rewards:          ConvertRewards(quest.Rewards),
+		ConditionOp:      string(quest.ConditionOp),
+		Conditions:       ConvertConditions(quest.Conditions),
+		CreatedAt:        quest.CreatedAt.Format(DefaultTimeLayout),
+		UpdatedAt:        quest.UpdatedAt.Format(DefaultTimeLayout),
+		IsHighlight:      quest.IsHighlight,
+		Position:         quest.Position,
+		MaxClaims:        quest.MaxClaims,
+		MaxClaimsPerUser: quest.MaxClaimsPerUser,
+		RemainingSlots:   remainingSlots,
+		StartAt:          startAt,
+		EndAt:            endAt,
+		RaffleWinners:    quest.RaffleWinners,
 	}
 }
 
@@ -249,6 +274,17 @@ func ConvertClaimedQuest(
 		reviewedAt = claimedQuest.ReviewedAt.Time.Format(DefaultTimeLayout)
 	}
 
+	var overriddenPoints *uint64
+	if claimedQuest.OverriddenPoints.Valid {
+		points := uint64(claimedQuest.OverriddenPoints.Int64)
+		overriddenPoints = &points
+	}
+
+	var rewardScale *float64
+	if claimedQuest.RewardScale.Valid {
+		rewardScale = &claimedQuest.RewardScale.Float64
+	}
+
 	return ClaimedQuest{
 		ID:             claimedQuest.ID,
 		Quest:          quest,
@@ -260,6 +296,23 @@ func ConvertClaimedQuest(
 		Comment:        claimedQuest.Comment,
 		CreatedAt:      claimedQuest.CreatedAt.Format(DefaultTimeLayout),
 		UpdatedAt:      claimedQuest.UpdatedAt.Format(DefaultTimeLayout),
+
+		OverriddenPoints: overriddenPoints,
+		RewardScale:      rewardScale,
+	}
+}
+
+func ConvertClaimedQuestHistory(history *entity.ClaimedQuestHistory) ClaimedQuestHistory {
+	if history == nil {
+		return ClaimedQuestHistory{}
+	}
+
+	return ClaimedQuestHistory{
+		ActorID:        history.ActorID,
+		Status:         string(history.Status),
+		SubmissionData: history.SubmissionData,
+		Comment:        history.Comment,
+		CreatedAt:      history.CreatedAt.Format(DefaultTimeLayout),
 	}
 }
 
@@ -22,7 +22,8 @@ type GetLotteryEventRequest struct {
 }
 
 type GetLotteryEventResponse struct {
-	Event LotteryEvent `json:"event"`
+	Event       LotteryEvent `json:"event"`
+	FreeTickets int          `json:"free_tickets"`
 }
 
 type BuyLotteryTicketsRequest struct {
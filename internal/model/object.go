@@ -33,6 +33,27 @@ type ClaimedQuest struct {
 	Comment        string    `json:"comment"`
 	CreatedAt      string    `json:"created_at"`
 	UpdatedAt      string    `json:"updated_at"`
+
+	OverriddenPoints *uint64  `json:"overridden_points,omitempty"`
+	RewardScale      *float64 `json:"reward_scale,omitempty"`
+
+	History []ClaimedQuestHistory `json:"history,omitempty"`
+}
+
+type ReviewerStatistic struct {
+	Reviewer   ShortUser `json:"reviewer"`
+	Accepted   uint64    `json:"accepted"`
+	Rejected   uint64    `json:"rejected"`
+	Unapproved uint64    `json:"unapproved"`
+	Leasing    int       `json:"leasing"`
+}
+
+type ClaimedQuestHistory struct {
+	ActorID        string `json:"actor_id"`
+	Status         string `json:"status"`
+	SubmissionData string `json:"submission_data"`
+	Comment        string `json:"comment"`
+	CreatedAt      string `json:"created_at"`
 }
 
 type Collaborator struct {
@@ -98,6 +119,12 @@ type Quest struct {
 	UnclaimableReasonMetadata map[string]any `json:"unclaimable_reason_metadata"`
 	IsHighlight               bool           `json:"is_highlight"`
 	Position                  int            `json:"position"`
+	MaxClaims                 int            `json:"max_claims"`
+	MaxClaimsPerUser          int            `json:"max_claims_per_user"`
+	RemainingSlots            *int           `json:"remaining_slots"`
+	StartAt                   string         `json:"start_at"`
+	EndAt                     string         `json:"end_at"`
+	RaffleWinners             int            `json:"raffle_winners"`
 }
 
 type CommunityStats struct {
@@ -319,3 +346,19 @@ type UserNonFungibleToken struct {
 	NonFungibleToken
 	UserBalance int `json:"user_balance"`
 }
+
+type SurveyAnswerCount struct {
+	Answer string `json:"answer"`
+	Count  int64  `json:"count"`
+}
+
+type SurveyQuestionResult struct {
+	Question      string              `json:"question"`
+	Type          string              `json:"type"`
+	Options       []string            `json:"options"`
+	MaxRating     int                 `json:"max_rating"`
+	Required      bool                `json:"required"`
+	Respondents   int64               `json:"respondents"`
+	AverageRating float64             `json:"average_rating"`
+	Answers       []SurveyAnswerCount `json:"answers"`
+}
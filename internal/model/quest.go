@@ -1,19 +1,26 @@
 package model
 
+import "time"
+
 type CreateQuestRequest struct {
-	CommunityHandle string         `json:"community_handle"`
-	Type            string         `json:"type"`
-	Title           string         `json:"title"`
-	Status          string         `json:"status"`
-	Description     string         `json:"description"`
-	CategoryID      string         `json:"category_id"`
-	Recurrence      string         `json:"recurrence"`
-	ValidationData  map[string]any `json:"validation_data"`
-	Points          uint64         `json:"points"`
-	Rewards         []Reward       `json:"rewards"`
-	ConditionOp     string         `json:"condition_op"`
-	Conditions      []Condition    `json:"conditions"`
-	IsHighlight     bool           `json:"is_highlight"`
+	CommunityHandle  string         `json:"community_handle"`
+	Type             string         `json:"type"`
+	Title            string         `json:"title"`
+	Status           string         `json:"status"`
+	Description      string         `json:"description"`
+	CategoryID       string         `json:"category_id"`
+	Recurrence       string         `json:"recurrence"`
+	ValidationData   map[string]any `json:"validation_data"`
+	Points           uint64         `json:"points"`
+	Rewards          []Reward       `json:"rewards"`
+	ConditionOp      string         `json:"condition_op"`
+	Conditions       []Condition    `json:"conditions"`
+	IsHighlight      bool           `json:"is_highlight"`
+	MaxClaims        int            `json:"max_claims"`
+	MaxClaimsPerUser int            `json:"max_claims_per_user"`
+	StartAt          time.Time      `json:"start_at"`
+	EndAt            time.Time      `json:"end_at"`
+	RaffleWinners    int            `json:"raffle_winners"`
 }
 
 type CreateQuestResponse struct {
@@ -63,19 +70,24 @@ type ParseQuestTemplatestResponse struct {
 }
 
 type UpdateQuestRequest struct {
-	ID             string         `json:"id"`
-	Status         string         `json:"status"`
-	Type           string         `json:"type"`
-	Title          string         `json:"title"`
-	Description    string         `json:"description"`
-	CategoryID     string         `json:"category_id"`
-	Recurrence     string         `json:"recurrence"`
-	ValidationData map[string]any `json:"validation_data"`
-	Points         uint64         `json:"points"`
-	Rewards        []Reward       `json:"rewards"`
-	ConditionOp    string         `json:"condition_op"`
-	Conditions     []Condition    `json:"conditions"`
-	IsHighlight    bool           `json:"is_highlight"`
+	ID               string         `json:"id"`
+	Status           string         `json:"status"`
+	Type             string         `json:"type"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	CategoryID       string         `json:"category_id"`
+	Recurrence       string         `json:"recurrence"`
+	ValidationData   map[string]any `json:"validation_data"`
+	Points           uint64         `json:"points"`
+	Rewards          []Reward       `json:"rewards"`
+	ConditionOp      string         `json:"condition_op"`
+	Conditions       []Condition    `json:"conditions"`
+	IsHighlight      bool           `json:"is_highlight"`
+	MaxClaims        int            `json:"max_claims"`
+	MaxClaimsPerUser int            `json:"max_claims_per_user"`
+	StartAt          time.Time      `json:"start_at"`
+	EndAt            time.Time      `json:"end_at"`
+	RaffleWinners    int            `json:"raffle_winners"`
 }
 
 type UpdateQuestResponse struct {
@@ -102,3 +114,60 @@ type DeleteQuestRequest struct {
 
 type DeleteQuestResponse struct {
 }
+
+// QuestBundle is a portable snapshot of quests and categories of a community.
+// IDs in the bundle are only used to link quests to categories and quest
+// conditions, they are replaced with new ones when importing.
+type QuestBundle struct {
+	Version    int                   `json:"version"`
+	Categories []QuestBundleCategory `json:"categories"`
+	Quests     []QuestBundleQuest    `json:"quests"`
+}
+
+type QuestBundleCategory struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type QuestBundleQuest struct {
+	ID               string         `json:"id"`
+	Type             string         `json:"type"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	CategoryID       string         `json:"category_id"`
+	Recurrence       string         `json:"recurrence"`
+	ValidationData   map[string]any `json:"validation_data"`
+	Points           uint64         `json:"points"`
+	Rewards          []Reward       `json:"rewards"`
+	ConditionOp      string         `json:"condition_op"`
+	Conditions       []Condition    `json:"conditions"`
+	IsHighlight      bool           `json:"is_highlight"`
+	Position         int            `json:"position"`
+	MaxClaims        int            `json:"max_claims"`
+	MaxClaimsPerUser int            `json:"max_claims_per_user"`
+	RaffleWinners    int            `json:"raffle_winners"`
+
+	// StartAt and EndAt are exported for reference only, they are ignored when
+	// importing.
+	StartAt *time.Time `json:"start_at,omitempty"`
+	EndAt   *time.Time `json:"end_at,omitempty"`
+}
+
+type ExportQuestsRequest struct {
+	CommunityHandle string `json:"community_handle"`
+}
+
+type ExportQuestsResponse struct {
+	Bundle QuestBundle `json:"bundle"`
+}
+
+type ImportQuestsRequest struct {
+	CommunityHandle string      `json:"community_handle"`
+	Bundle          QuestBundle `json:"bundle"`
+}
+
+type ImportQuestsResponse struct {
+	// QuestIDs maps ids of quests in the bundle to ids of the imported ones.
+	QuestIDs map[string]string `json:"quest_ids"`
+}
@@ -5,12 +5,16 @@ import (
 
 	"github.com/questx-lab/backend/internal/entity"
 	"github.com/questx-lab/backend/pkg/xcontext"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type BadgeRepository interface {
 	Create(ctx context.Context, badge *entity.Badge) error
-	Get(ctx context.Context, name string, level int) (*entity.Badge, error)
+	CreateIfNotExists(ctx context.Context, badge *entity.Badge) error
+	UpsertSystemBadge(ctx context.Context, badge *entity.Badge) error
+	Get(ctx context.Context, communityID, name string, level int) (*entity.Badge, error)
+	GetForUpdate(ctx context.Context, communityID, name string, level int) (*entity.Badge, error)
 	GetByID(ctx context.Context, id string) (*entity.Badge, error)
 	GetLessThanValue(ctx context.Context, name string, value int) ([]entity.Badge, error)
 	GetAll(ctx context.Context) ([]entity.Badge, error)
@@ -23,23 +27,54 @@ func NewBadgeRepository() *badgeRepository {
 }
 
 func (r *badgeRepository) Create(ctx context.Context, badge *entity.Badge) error {
+	return xcontext.DB(ctx).Create(badge).Error
+}
+
+// CreateIfNotExists creates the badge, or does nothing if the badge with the
+// same community, name and level existed.
+func (r *badgeRepository) CreateIfNotExists(ctx context.Context, badge *entity.Badge) error {
+	return xcontext.DB(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(badge).Error
+}
+
+// UpsertSystemBadge creates a badge which doesn't belong to any community, or
+// updates it if the badge with the same name and level existed.
+func (r *badgeRepository) UpsertSystemBadge(ctx context.Context, badge *entity.Badge) error {
 	return xcontext.DB(ctx).
 		Clauses(clause.OnConflict{
 			Columns: []clause.Column{
+				{Name: "community_key"},
 				{Name: "name"},
 				{Name: "level"},
 			},
-			DoUpdates: clause.Assignments(map[string]any{
-				"value":       badge.Value,
-				"description": badge.Description,
-				"icon_url":    badge.IconURL,
-			}),
+			DoUpdates: clause.AssignmentColumns([]string{"value", "description", "icon_url"}),
 		}).Create(badge).Error
 }
 
-func (r *badgeRepository) Get(ctx context.Context, name string, level int) (*entity.Badge, error) {
+// Get returns the badge of the community, or the system badge if the community
+// id is empty. System badges are also visible to all communities.
+func (r *badgeRepository) Get(ctx context.Context, communityID, name string, level int) (*entity.Badge, error) {
+	return r.get(xcontext.DB(ctx), communityID, name, level)
+}
+
+// GetForUpdate is the same as Get, but it also locks the badge row until the
+// end of the current transaction. Unlike Get, it always reads the latest
+// committed row.
+func (r *badgeRepository) GetForUpdate(ctx context.Context, communityID, name string, level int) (*entity.Badge, error) {
+	return r.get(xcontext.DB(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), communityID, name, level)
+}
+
+func (r *badgeRepository) get(tx *gorm.DB, communityID, name string, level int) (*entity.Badge, error) {
+	tx = tx.Where("name=? AND level=?", name, level)
+	if communityID == "" {
+		tx = tx.Where("community_id IS NULL")
+	} else {
+		tx = tx.Where("community_id IS NULL OR community_id=?", communityID)
+	}
+
 	result := &entity.Badge{}
-	if err := xcontext.DB(ctx).Where("name=? AND level=?", name, level).Take(result).Error; err != nil {
+	if err := tx.Take(result).Error; err != nil {
 		return nil, err
 	}
 
@@ -48,7 +83,7 @@ func (r *badgeRepository) Get(ctx context.Context, name string, level int) (*ent
 
 func (r *badgeRepository) GetByID(ctx context.Context, id string) (*entity.Badge, error) {
 	result := &entity.Badge{}
-	if err := xcontext.DB(ctx).Where("id?", id).Take(result).Error; err != nil {
+	if err := xcontext.DB(ctx).Where("id=?", id).Take(result).Error; err != nil {
 		return nil, err
 	}
 
@@ -67,7 +102,7 @@ func (r *badgeRepository) GetAll(ctx context.Context) ([]entity.Badge, error) {
 func (r *badgeRepository) GetLessThanValue(ctx context.Context, name string, value int) ([]entity.Badge, error) {
 	result := []entity.Badge{}
 	err := xcontext.DB(ctx).
-		Where("name=? AND value<=?", name, value).
+		Where("community_id IS NULL AND name=? AND value<=?", name, value).
 		Order("level ASC").
 		Find(&result).Error
 	if err != nil {
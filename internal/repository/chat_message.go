@@ -16,6 +16,7 @@ type LastMessageFilter struct {
 	ToBucket   int64
 	ChannelID  int64
 	Before     int64
+	After      int64
 	Limit      int64
 }
 
@@ -105,6 +106,9 @@ func (r *chatMessageRepository) GetListByLastMessage(ctx context.Context, filter
 	if filter.Before != 0 {
 		builder = builder.Where(qb.Lt("id"))
 	}
+	if filter.After != 0 {
+		builder = builder.Where(qb.GtNamed("id", "after"))
+	}
 	stmt, names := builder.ToCql()
 	query := gocqlx.Session.Query(r.session, stmt, names)
 
@@ -118,6 +122,9 @@ func (r *chatMessageRepository) GetListByLastMessage(ctx context.Context, filter
 		if filter.Before != 0 {
 			binder["id"] = filter.Before
 		}
+		if filter.After != 0 {
+			binder["after"] = filter.After
+		}
 
 		var messages []entity.ChatMessage
 		if err := query.BindMap(binder).Select(&messages); err != nil {
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/questx-lab/backend/internal/entity"
 	"github.com/questx-lab/backend/pkg/xcontext"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ClaimedQuestFilter struct {
@@ -18,17 +21,25 @@ type ClaimedQuestFilter struct {
 	Offset      int
 	Limit       int
 	ReverseTime bool
+	ExcludeIDs  []string
+
+	// If AfterID is set, only claimed quests after (AfterCreatedAt, AfterID)
+	// are returned. It is used for keyset pagination in ascending order.
+	AfterCreatedAt time.Time
+	AfterID        string
 }
 
 type GetLastClaimedQuestFilter struct {
-	UserID      string
-	QuestID     string
-	CommunityID string
-	Status      []entity.ClaimedQuestStatus
+	UserID         string
+	QuestID        string
+	CommunityID    string
+	SubmissionData string
+	Status         []entity.ClaimedQuestStatus
 }
 
 type StatisticClaimedQuestFilter struct {
 	CommunityID   string
+	QuestID       string
 	UserID        string
 	Status        []entity.ClaimedQuestStatus
 	ReviewedStart time.Time
@@ -38,12 +49,20 @@ type StatisticClaimedQuestFilter struct {
 type ClaimedQuestRepository interface {
 	Create(context.Context, *entity.ClaimedQuest) error
 	Count(ctx context.Context, filter StatisticClaimedQuestFilter) (int64, error)
+	CountUserClaims(ctx context.Context, questID, userID string, status []entity.ClaimedQuestStatus) (int64, error)
 	GetByID(context.Context, string) (*entity.ClaimedQuest, error)
 	GetByIDs(context.Context, []string) ([]entity.ClaimedQuest, error)
 	GetLast(ctx context.Context, filter GetLastClaimedQuestFilter) (*entity.ClaimedQuest, error)
 	GetList(ctx context.Context, filter *ClaimedQuestFilter) ([]entity.ClaimedQuest, error)
 	UpdateReviewByIDs(ctx context.Context, ids []string, data *entity.ClaimedQuest) error
+	UpdateOverrideByIDs(ctx context.Context, ids []string, points sql.NullInt64, rewardScale sql.NullFloat64) error
 	Statistic(ctx context.Context, filter StatisticClaimedQuestFilter) ([]entity.UserStatistic, error)
+	Appeal(ctx context.Context, id, submissionData string) error
+	UpdatePendingSubmission(ctx context.Context, id string, data *entity.ClaimedQuest) error
+	CreateHistories(ctx context.Context, histories []entity.ClaimedQuestHistory) error
+	GetHistories(ctx context.Context, claimedQuestID string) ([]entity.ClaimedQuestHistory, error)
+	CountHistories(ctx context.Context, claimedQuestID string, status entity.ClaimedQuestStatus) (int64, error)
+	ReviewerStatistic(ctx context.Context, communityID string, start, end time.Time) ([]entity.ReviewerStatistic, error)
 }
 
 type claimedQuestRepository struct{}
@@ -97,6 +116,10 @@ func (r *claimedQuestRepository) GetLast(
 		tx = tx.Where("quests.community_id=?", filter.CommunityID)
 	}
 
+	if filter.SubmissionData != "" {
+		tx = tx.Where("claimed_quests.submission_data=?", filter.SubmissionData)
+	}
+
 	if len(filter.Status) > 0 {
 		tx = tx.Where("claimed_quests.status in (?)", filter.Status)
 	}
@@ -119,9 +142,14 @@ func (r *claimedQuestRepository) GetList(
 		Limit(filter.Limit)
 
 	if filter.ReverseTime {
-		tx.Order("claimed_quests.created_at DESC")
+		tx.Order("claimed_quests.created_at DESC").Order("claimed_quests.id DESC")
 	} else {
-		tx.Order("claimed_quests.created_at ASC")
+		tx.Order("claimed_quests.created_at ASC").Order("claimed_quests.id ASC")
+	}
+
+	if filter.AfterID != "" {
+		tx.Where("(claimed_quests.created_at > ? OR (claimed_quests.created_at = ? AND claimed_quests.id > ?))",
+			filter.AfterCreatedAt, filter.AfterCreatedAt, filter.AfterID)
 	}
 
 	if filter.CommunityID != "" {
@@ -144,6 +172,10 @@ func (r *claimedQuestRepository) GetList(
 		tx.Where("claimed_quests.user_id IN (?)", filter.UserIDs)
 	}
 
+	if len(filter.ExcludeIDs) > 0 {
+		tx.Where("claimed_quests.id NOT IN (?)", filter.ExcludeIDs)
+	}
+
 	err := tx.Find(&result).Error
 	if err != nil {
 		return nil, err
@@ -165,6 +197,18 @@ func (r *claimedQuestRepository) UpdateReviewByIDs(ctx context.Context, ids []st
 	return nil
 }
 
+func (r *claimedQuestRepository) UpdateOverrideByIDs(
+	ctx context.Context, ids []string, points sql.NullInt64, rewardScale sql.NullFloat64,
+) error {
+	return xcontext.DB(ctx).
+		Model(&entity.ClaimedQuest{}).
+		Where("id IN (?)", ids).
+		Updates(map[string]any{
+			"overridden_points": points,
+			"reward_scale":      rewardScale,
+		}).Error
+}
+
 func (r *claimedQuestRepository) Count(ctx context.Context, filter StatisticClaimedQuestFilter) (int64, error) {
 	tx := xcontext.DB(ctx).Model(&entity.ClaimedQuest{}).
 		Joins("join quests on quests.id = claimed_quests.quest_id")
@@ -173,6 +217,10 @@ func (r *claimedQuestRepository) Count(ctx context.Context, filter StatisticClai
 		tx = tx.Where("quests.community_id=?", filter.CommunityID)
 	}
 
+	if filter.QuestID != "" {
+		tx = tx.Where("claimed_quests.quest_id = ?", filter.QuestID)
+	}
+
 	if filter.UserID != "" {
 		tx = tx.Where("claimed_quests.user_id = ?", filter.UserID)
 	}
@@ -197,11 +245,29 @@ func (r *claimedQuestRepository) Count(ctx context.Context, filter StatisticClai
 	return result, nil
 }
 
+// CountUserClaims counts claims of the user in the quest with a locking read,
+// so it always sees the latest committed claims inside a transaction.
+func (r *claimedQuestRepository) CountUserClaims(
+	ctx context.Context, questID, userID string, status []entity.ClaimedQuestStatus,
+) (int64, error) {
+	var result int64
+	err := xcontext.DB(ctx).
+		Model(&entity.ClaimedQuest{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("quest_id=? AND user_id=? AND status IN (?)", questID, userID, status).
+		Count(&result).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
 func (r *claimedQuestRepository) Statistic(
 	ctx context.Context, filter StatisticClaimedQuestFilter,
 ) ([]entity.UserStatistic, error) {
 	tx := xcontext.DB(ctx).Model(&entity.ClaimedQuest{}).
-		Select("SUM(quests.points) as points, COUNT(*) as quests, quests.community_id, claimed_quests.user_id").
+		Select("SUM(COALESCE(claimed_quests.overridden_points, quests.points)) as points, COUNT(*) as quests, quests.community_id, claimed_quests.user_id").
 		Joins("join quests on quests.id = claimed_quests.quest_id").
 		Group("claimed_quests.user_id")
 
@@ -232,3 +298,127 @@ func (r *claimedQuestRepository) Statistic(
 
 	return result, nil
 }
+
+// Appeal moves a rejected claimed quest to appealed status. It returns
+// ErrRecordNotFound if the claimed quest is not rejected.
+func (r *claimedQuestRepository) Appeal(ctx context.Context, id, submissionData string) error {
+	updates := map[string]any{"status": entity.Appealed}
+	if submissionData != "" {
+		updates["submission_data"] = submissionData
+	}
+
+	tx := xcontext.DB(ctx).
+		Model(&entity.ClaimedQuest{}).
+		Where("id=? AND status IN (?)", id, []entity.ClaimedQuestStatus{entity.Rejected, entity.AutoRejected}).
+		Updates(updates)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdatePendingSubmission replaces the submission data and status of a pending
+// claimed quest. It returns ErrRecordNotFound if the claimed quest is not
+// pending.
+func (r *claimedQuestRepository) UpdatePendingSubmission(
+	ctx context.Context, id string, data *entity.ClaimedQuest,
+) error {
+	tx := xcontext.DB(ctx).
+		Model(&entity.ClaimedQuest{}).
+		Where("id=? AND status=?", id, entity.Pending).
+		Updates(map[string]any{
+			"submission_data": data.SubmissionData,
+			"status":          data.Status,
+			"reviewed_at":     data.ReviewedAt,
+		})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *claimedQuestRepository) CreateHistories(
+	ctx context.Context, histories []entity.ClaimedQuestHistory,
+) error {
+	if len(histories) == 0 {
+		return nil
+	}
+
+	return xcontext.DB(ctx).Create(&histories).Error
+}
+
+func (r *claimedQuestRepository) GetHistories(
+	ctx context.Context, claimedQuestID string,
+) ([]entity.ClaimedQuestHistory, error) {
+	var result []entity.ClaimedQuestHistory
+	err := xcontext.DB(ctx).
+		Where("claimed_quest_id=?", claimedQuestID).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *claimedQuestRepository) CountHistories(
+	ctx context.Context, claimedQuestID string, status entity.ClaimedQuestStatus,
+) (int64, error) {
+	var result int64
+	err := xcontext.DB(ctx).
+		Model(&entity.ClaimedQuestHistory{}).
+		Where("claimed_quest_id=? AND status=?", claimedQuestID, status).
+		Count(&result).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
+// ReviewerStatistic counts review decisions of each reviewer in the community.
+// Histories made by the claimer (claiming, appealing) are ignored.
+func (r *claimedQuestRepository) ReviewerStatistic(
+	ctx context.Context, communityID string, start, end time.Time,
+) ([]entity.ReviewerStatistic, error) {
+	tx := xcontext.DB(ctx).Model(&entity.ClaimedQuestHistory{}).
+		Select("claimed_quest_histories.actor_id as reviewer_id, "+
+			"SUM(CASE WHEN claimed_quest_histories.status = ? THEN 1 ELSE 0 END) as accepted, "+
+			"SUM(CASE WHEN claimed_quest_histories.status = ? THEN 1 ELSE 0 END) as rejected, "+
+			"SUM(CASE WHEN claimed_quest_histories.status = ? THEN 1 ELSE 0 END) as unapproved",
+			entity.Accepted, entity.Rejected, entity.Pending).
+		Joins("join claimed_quests on claimed_quests.id = claimed_quest_histories.claimed_quest_id").
+		Joins("join quests on quests.id = claimed_quests.quest_id").
+		Where("quests.community_id = ?", communityID).
+		Where("claimed_quest_histories.actor_id != claimed_quests.user_id").
+		Where("claimed_quest_histories.status IN (?)",
+			[]entity.ClaimedQuestStatus{entity.Accepted, entity.Rejected, entity.Pending}).
+		Group("claimed_quest_histories.actor_id")
+
+	if !start.IsZero() {
+		tx.Where("claimed_quest_histories.created_at >= ?", start)
+	}
+
+	if !end.IsZero() {
+		tx.Where("claimed_quest_histories.created_at < ?", end)
+	}
+
+	var result []entity.ReviewerStatistic
+	if err := tx.Scan(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -13,7 +13,10 @@ import (
 )
 
 type StatisticFollowerFilter struct {
-	UserID string
+	UserID      string
+	CommunityID string
+	InvitedBy   string
+	MinQuests   uint64
 }
 
 type GetListFollowerFilter struct {
@@ -26,6 +29,7 @@ type GetListFollowerFilter struct {
 
 type FollowerRepository interface {
 	Get(ctx context.Context, userID, communityID string) (*entity.Follower, error)
+	GetForUpdate(ctx context.Context, userID, communityID string) (*entity.Follower, error)
 	GetListByCommunityID(ctx context.Context, filter GetListFollowerFilter) ([]entity.Follower, error)
 	GetListByUserID(ctx context.Context, userID string) ([]entity.Follower, error)
 	GetByReferralCode(ctx context.Context, code string) (*entity.Follower, error)
@@ -59,6 +63,21 @@ func (r *followerRepository) Get(ctx context.Context, userID, communityID string
 	return &result, nil
 }
 
+// GetForUpdate gets the follower and locks its row until the end of the
+// current transaction.
+func (r *followerRepository) GetForUpdate(ctx context.Context, userID, communityID string) (*entity.Follower, error) {
+	var result entity.Follower
+	err := xcontext.DB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id=? AND community_id=?", userID, communityID).
+		Take(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (r *followerRepository) GetListByCommunityID(
 	ctx context.Context, filter GetListFollowerFilter,
 ) ([]entity.Follower, error) {
@@ -346,6 +365,18 @@ func (r *followerRepository) Count(ctx context.Context, filter StatisticFollower
 		tx = tx.Where("user_id = ?", filter.UserID)
 	}
 
+	if filter.CommunityID != "" {
+		tx = tx.Where("community_id = ?", filter.CommunityID)
+	}
+
+	if filter.InvitedBy != "" {
+		tx = tx.Where("invited_by = ?", filter.InvitedBy)
+	}
+
+	if filter.MinQuests > 0 {
+		tx = tx.Where("quests >= ?", filter.MinQuests)
+	}
+
 	var result int64
 	if err := tx.Count(&result).Error; err != nil {
 		return 0, err
@@ -6,6 +6,7 @@ import (
 	"github.com/questx-lab/backend/internal/entity"
 	"github.com/questx-lab/backend/pkg/xcontext"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type LotteryRepository interface {
@@ -27,6 +28,12 @@ type LotteryRepository interface {
 	GetWinnerByID(ctx context.Context, winnerID string) (*entity.LotteryWinner, error)
 	GetNotClaimedWinnerByUserID(ctx context.Context, userID string) ([]entity.LotteryWinner, error)
 	ClaimWinnerReward(ctx context.Context, winnerID string) error
+
+	// Free ticket
+	IncreaseFreeTickets(ctx context.Context, eventID, userID string, tickets int) error
+	GetFreeTickets(ctx context.Context, eventID, userID string) (int, error)
+	DecreaseFreeTickets(ctx context.Context, eventID, userID string, tickets int) error
+	UseFreeTicket(ctx context.Context, eventID, userID string) error
 }
 
 type lotteryRepository struct{}
@@ -156,3 +163,56 @@ func (r *lotteryRepository) GetNotClaimedWinnerByUserID(ctx context.Context, use
 
 	return result, nil
 }
+
+func (r *lotteryRepository) IncreaseFreeTickets(ctx context.Context, eventID, userID string, tickets int) error {
+	return xcontext.DB(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "lottery_event_id"},
+				{Name: "user_id"},
+			},
+			DoUpdates: clause.Assignments(map[string]any{
+				"tickets": gorm.Expr("tickets+?", tickets),
+			}),
+		}).
+		Create(&entity.LotteryFreeTicket{
+			LotteryEventID: eventID,
+			UserID:         userID,
+			Tickets:        tickets,
+		}).Error
+}
+
+func (r *lotteryRepository) GetFreeTickets(ctx context.Context, eventID, userID string) (int, error) {
+	var result entity.LotteryFreeTicket
+	err := xcontext.DB(ctx).
+		Where("lottery_event_id=? AND user_id=?", eventID, userID).
+		Take(&result).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Tickets, nil
+}
+
+// DecreaseFreeTickets decreases the number of unused free tickets of user. The
+// tickets which were used before are not affected.
+func (r *lotteryRepository) DecreaseFreeTickets(ctx context.Context, eventID, userID string, tickets int) error {
+	return xcontext.DB(ctx).Model(&entity.LotteryFreeTicket{}).
+		Where("lottery_event_id=? AND user_id=?", eventID, userID).
+		Update("tickets", gorm.Expr("CASE WHEN tickets>? THEN tickets-? ELSE 0 END", tickets, tickets)).Error
+}
+
+func (r *lotteryRepository) UseFreeTicket(ctx context.Context, eventID, userID string) error {
+	tx := xcontext.DB(ctx).Model(&entity.LotteryFreeTicket{}).
+		Where("lottery_event_id=? AND user_id=? AND tickets>0", eventID, userID).
+		Update("tickets", gorm.Expr("tickets-1"))
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ type NftRepository interface {
 	CreateHistory(context.Context, *entity.NonFungibleTokenMintHistory) error
 
 	// Claimed
+	GetClaimedToken(ctx context.Context, userID string, tokenID int64) (*entity.ClaimedNonFungibleToken, error)
 	UpsertClaimedToken(context.Context, *entity.ClaimedNonFungibleToken) error
 }
 
@@ -97,6 +98,18 @@ func (r *nftRepository) GetByUserID(ctx context.Context, userID string) ([]entit
 	return result, nil
 }
 
+func (r *nftRepository) GetClaimedToken(ctx context.Context, userID string, tokenID int64) (*entity.ClaimedNonFungibleToken, error) {
+	var result entity.ClaimedNonFungibleToken
+	err := xcontext.DB(ctx).
+		Where("user_id=? AND non_fungible_token_id=?", userID, tokenID).
+		Take(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (r *nftRepository) UpsertClaimedToken(ctx context.Context, data *entity.ClaimedNonFungibleToken) error {
 	return xcontext.DB(ctx).
 		Clauses(clause.OnConflict{
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/questx-lab/backend/internal/client"
 	"github.com/questx-lab/backend/internal/domain/search"
@@ -19,6 +20,13 @@ type SearchQuestFilter struct {
 	Limit       int
 }
 
+type ScheduledQuestFilter struct {
+	Status         entity.QuestStatusType
+	StartedBefore  time.Time
+	EndedBefore    time.Time
+	NeverActivated bool
+}
+
 type StatisticQuestFilter struct {
 	CommunityID string
 }
@@ -31,6 +39,9 @@ type QuestRepository interface {
 	GetByIDsIncludeSoftDeleted(ctx context.Context, ids []string) ([]entity.Quest, error)
 	GetList(ctx context.Context, filter SearchQuestFilter) ([]entity.Quest, error)
 	GetTemplates(ctx context.Context, filter SearchQuestFilter) ([]entity.Quest, error)
+	GetScheduled(ctx context.Context, filter ScheduledQuestFilter) ([]entity.Quest, error)
+	UpdateStatus(ctx context.Context, id string, from, to entity.QuestStatusType) (bool, error)
+	GetUndrawnRaffles(ctx context.Context, endedBefore time.Time) ([]entity.Quest, error)
 	Save(ctx context.Context, data *entity.Quest) error
 	Delete(ctx context.Context, data *entity.Quest) error
 	Count(ctx context.Context, filter StatisticQuestFilter) (int64, error)
@@ -39,6 +50,8 @@ type QuestRepository interface {
 	IncreasePosition(ctx context.Context, communityID, categoryID string, from, to int) error
 	DecreasePosition(ctx context.Context, communityID, categoryID string, from, to int) error
 	RemoveQuestCategory(ctx context.Context, communityID, categoryID string) error
+	IncreaseClaimedCount(ctx context.Context, questID string) (bool, error)
+	DecreaseClaimedCount(ctx context.Context, questID string) error
 }
 
 type questRepository struct {
@@ -183,8 +196,74 @@ func (r *questRepository) GetByIDsIncludeSoftDeleted(ctx context.Context, ids []
 	return result, nil
 }
 
+func (r *questRepository) GetScheduled(
+	ctx context.Context, filter ScheduledQuestFilter,
+) ([]entity.Quest, error) {
+	var result []entity.Quest
+	tx := xcontext.DB(ctx).Model(&entity.Quest{}).
+		Where("is_template=false").
+		Where("status=?", filter.Status)
+
+	if !filter.StartedBefore.IsZero() {
+		tx.Where("start_at IS NOT NULL AND start_at<=?", filter.StartedBefore)
+		tx.Where("end_at IS NULL OR end_at>?", filter.StartedBefore)
+	}
+
+	if !filter.EndedBefore.IsZero() {
+		tx.Where("end_at IS NOT NULL AND end_at<=?", filter.EndedBefore)
+	}
+
+	if filter.NeverActivated {
+		tx.Where("activated_at IS NULL")
+	}
+
+	if err := tx.Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *questRepository) GetUndrawnRaffles(ctx context.Context, endedBefore time.Time) ([]entity.Quest, error) {
+	var result []entity.Quest
+	err := xcontext.DB(ctx).Model(&entity.Quest{}).
+		Joins("left join quest_raffles on quest_raffles.quest_id = quests.id").
+		Where("quests.raffle_winners>0").
+		Where("quests.end_at IS NOT NULL AND quests.end_at<=?", endedBefore).
+		Where("quest_raffles.quest_id IS NULL").
+		Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateStatus changes the status of quest only if its current status is the
+// expected one. It returns false if the status was changed by someone else.
+func (r *questRepository) UpdateStatus(
+	ctx context.Context, id string, from, to entity.QuestStatusType,
+) (bool, error) {
+	updates := map[string]any{"status": to}
+	if to == entity.QuestActive {
+		updates["activated_at"] = gorm.Expr("COALESCE(activated_at, ?)", time.Now())
+	}
+
+	tx := xcontext.DB(ctx).
+		Model(&entity.Quest{}).
+		Where("id=? AND status=?", id, from).
+		Updates(updates)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	return tx.RowsAffected == 1, nil
+}
+
 func (r *questRepository) Save(ctx context.Context, data *entity.Quest) error {
-	if err := xcontext.DB(ctx).Save(data).Error; err != nil {
+	// The claimed_count is only modified by IncreaseClaimedCount and
+	// DecreaseClaimedCount, do not override it by a stale value.
+	if err := xcontext.DB(ctx).Omit("claimed_count").Save(data).Error; err != nil {
 		return err
 	}
 
@@ -314,3 +393,26 @@ func (r *questRepository) RemoveQuestCategory(ctx context.Context, communityID,
 		Where("community_id=? AND category_id=?", communityID, categoryID).
 		Update("category_id", nil).Error
 }
+
+// IncreaseClaimedCount increases the number of accepted claims of the quest if
+// it has not reached the max claims yet. It returns false if the quest is out
+// of slots.
+func (r *questRepository) IncreaseClaimedCount(ctx context.Context, questID string) (bool, error) {
+	tx := xcontext.DB(ctx).
+		Model(&entity.Quest{}).
+		Where("id=?", questID).
+		Where("max_claims=0 OR claimed_count<max_claims").
+		Update("claimed_count", gorm.Expr("claimed_count+1"))
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	return tx.RowsAffected == 1, nil
+}
+
+func (r *questRepository) DecreaseClaimedCount(ctx context.Context, questID string) error {
+	return xcontext.DB(ctx).
+		Model(&entity.Quest{}).
+		Where("id=? AND claimed_count>0", questID).
+		Update("claimed_count", gorm.Expr("claimed_count-1")).Error
+}
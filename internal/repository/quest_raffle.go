@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/pkg/xcontext"
+)
+
+type QuestRaffleRepository interface {
+	Create(ctx context.Context, raffle *entity.QuestRaffle) error
+	GetByQuestID(ctx context.Context, questID string) (*entity.QuestRaffle, error)
+}
+
+type questRaffleRepository struct{}
+
+func NewQuestRaffleRepository() *questRaffleRepository {
+	return &questRaffleRepository{}
+}
+
+func (r *questRaffleRepository) Create(ctx context.Context, raffle *entity.QuestRaffle) error {
+	return xcontext.DB(ctx).Create(raffle).Error
+}
+
+func (r *questRaffleRepository) GetByQuestID(ctx context.Context, questID string) (*entity.QuestRaffle, error) {
+	var result entity.QuestRaffle
+	if err := xcontext.DB(ctx).Take(&result, "quest_id=?", questID).Error; err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/pkg/xcontext"
+)
+
+type SurveyAnswerRepository interface {
+	CreateMany(ctx context.Context, answers []entity.SurveyAnswer) error
+	GetByClaimedQuestID(ctx context.Context, claimedQuestID string) ([]entity.SurveyAnswer, error)
+	Statistic(ctx context.Context, questID string) ([]entity.SurveyAnswerStatistic, error)
+	CountRespondents(ctx context.Context, questID string) ([]entity.SurveyAnswerStatistic, error)
+}
+
+type surveyAnswerRepository struct{}
+
+func NewSurveyAnswerRepository() *surveyAnswerRepository {
+	return &surveyAnswerRepository{}
+}
+
+func (r *surveyAnswerRepository) CreateMany(ctx context.Context, answers []entity.SurveyAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	return xcontext.DB(ctx).Create(&answers).Error
+}
+
+func (r *surveyAnswerRepository) GetByClaimedQuestID(
+	ctx context.Context, claimedQuestID string,
+) ([]entity.SurveyAnswer, error) {
+	var result []entity.SurveyAnswer
+	err := xcontext.DB(ctx).
+		Where("claimed_quest_id=?", claimedQuestID).
+		Order("question ASC, position ASC").
+		Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Statistic counts the number of times each answer was chosen for every
+// question of the quest. Answers of rejected claimed quests are ignored.
+func (r *surveyAnswerRepository) Statistic(
+	ctx context.Context, questID string,
+) ([]entity.SurveyAnswerStatistic, error) {
+	var result []entity.SurveyAnswerStatistic
+	err := xcontext.DB(ctx).Model(&entity.SurveyAnswer{}).
+		Select("survey_answers.question, survey_answers.answer, COUNT(*) as count").
+		Joins("join claimed_quests on claimed_quests.id = survey_answers.claimed_quest_id").
+		Where("survey_answers.quest_id = ?", questID).
+		Where("claimed_quests.status NOT IN (?)",
+			[]entity.ClaimedQuestStatus{entity.Rejected, entity.AutoRejected}).
+		Group("survey_answers.question, survey_answers.answer").
+		Order("survey_answers.question ASC, count DESC").
+		Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CountRespondents counts the number of claimed quests answering each
+// question of the quest. The Answer field of result is always empty.
+func (r *surveyAnswerRepository) CountRespondents(
+	ctx context.Context, questID string,
+) ([]entity.SurveyAnswerStatistic, error) {
+	var result []entity.SurveyAnswerStatistic
+	err := xcontext.DB(ctx).Model(&entity.SurveyAnswer{}).
+		Select("survey_answers.question, COUNT(DISTINCT survey_answers.claimed_quest_id) as count").
+		Joins("join claimed_quests on claimed_quests.id = survey_answers.claimed_quest_id").
+		Where("survey_answers.quest_id = ?", questID).
+		Where("claimed_quests.status NOT IN (?)",
+			[]entity.ClaimedQuestStatus{entity.Rejected, entity.AutoRejected}).
+		Group("survey_answers.question").
+		Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -156,6 +156,15 @@ func AutoMigrate(ctx context.Context) error {
 		&entity.Migration{},
 		&entity.PayReward{},
 		&entity.Role{},
+		&entity.SurveyAnswer{},
+		&entity.QuestRaffle{},
+		&entity.ClaimedQuestHistory{},
+		&entity.ChatChannel{},
+		&entity.LotteryEvent{},
+		&entity.LotteryPrize{},
+		&entity.LotteryWinner{},
+		&entity.LotteryFreeTicket{},
+		&entity.ClaimedNonFungibleToken{},
 	)
 }
 
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `survey_answers` (
+  `claimed_quest_id` varchar(256),
+  `question` bigint,
+  `position` bigint,
+  `quest_id` varchar(256),
+  `answer` text,
+  PRIMARY KEY (`claimed_quest_id`,`question`,`position`),
+  INDEX `idx_survey_answers_quest_id` (`quest_id`),
+  CONSTRAINT `fk_survey_answers_claimed_quest` FOREIGN KEY (`claimed_quest_id`) REFERENCES `claimed_quests`(`id`),
+  CONSTRAINT `fk_survey_answers_quest` FOREIGN KEY (`quest_id`) REFERENCES `quests`(`id`)
+);
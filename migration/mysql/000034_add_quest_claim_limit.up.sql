@@ -0,0 +1,9 @@
+ALTER TABLE `quests` ADD IF NOT EXISTS `max_claims` BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE `quests` ADD IF NOT EXISTS `claimed_count` BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE `quests` ADD IF NOT EXISTS `max_claims_per_user` BIGINT NOT NULL DEFAULT 0;
+
+UPDATE `quests` SET `claimed_count` = (
+    SELECT COUNT(*) FROM `claimed_quests`
+    WHERE `claimed_quests`.`quest_id` = `quests`.`id`
+    AND `claimed_quests`.`status` IN ('accepted', 'auto_accepted')
+);
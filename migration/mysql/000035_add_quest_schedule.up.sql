@@ -0,0 +1,2 @@
+ALTER TABLE `quests` ADD IF NOT EXISTS `start_at` DATETIME NULL;
+ALTER TABLE `quests` ADD IF NOT EXISTS `end_at` DATETIME NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE `badges` ADD IF NOT EXISTS `community_id` VARCHAR(256) NULL;
+ALTER TABLE `badges` ADD CONSTRAINT `fk_badges_community` FOREIGN KEY IF NOT EXISTS (`community_id`) REFERENCES `communities`(`id`);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `lottery_free_tickets` (
+  `lottery_event_id` varchar(256),
+  `user_id` varchar(256),
+  `tickets` bigint,
+  PRIMARY KEY (`lottery_event_id`,`user_id`),
+  CONSTRAINT `fk_lottery_free_tickets_lottery_event` FOREIGN KEY (`lottery_event_id`) REFERENCES `lottery_events`(`id`),
+  CONSTRAINT `fk_lottery_free_tickets_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`)
+);
@@ -0,0 +1,11 @@
+ALTER TABLE `quests` ADD IF NOT EXISTS `raffle_winners` BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS `quest_raffles` (
+  `quest_id` varchar(256),
+  `seed` bigint,
+  `participants` bigint,
+  `winners` bigint,
+  `drawn_at` datetime NULL,
+  PRIMARY KEY (`quest_id`),
+  CONSTRAINT `fk_quest_raffles_quest` FOREIGN KEY (`quest_id`) REFERENCES `quests`(`id`)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `claimed_quests` ADD IF NOT EXISTS `overridden_points` BIGINT NULL;
+ALTER TABLE `claimed_quests` ADD IF NOT EXISTS `reward_scale` DOUBLE NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `claimed_quest_histories` (
+  `id` varchar(256),
+  `created_at` datetime NULL,
+  `updated_at` datetime NULL,
+  `deleted_at` datetime NULL,
+  `claimed_quest_id` varchar(256),
+  `actor_id` varchar(256),
+  `status` varchar(256),
+  `submission_data` text,
+  `comment` text,
+  PRIMARY KEY (`id`),
+  INDEX `idx_claimed_quest_histories_deleted_at` (`deleted_at`),
+  INDEX `idx_claimed_quest_histories_claimed_quest_id` (`claimed_quest_id`),
+  CONSTRAINT `fk_claimed_quest_histories_claimed_quest` FOREIGN KEY (`claimed_quest_id`) REFERENCES `claimed_quests`(`id`),
+  CONSTRAINT `fk_claimed_quest_histories_actor` FOREIGN KEY (`actor_id`) REFERENCES `users`(`id`)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `quests` ADD IF NOT EXISTS `activated_at` DATETIME NULL;
+UPDATE `quests` SET `activated_at` = `updated_at` WHERE `activated_at` IS NULL AND `status` IN ('active', 'archived');
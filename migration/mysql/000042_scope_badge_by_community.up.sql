@@ -0,0 +1,2 @@
+ALTER TABLE `badges` DROP INDEX IF EXISTS `idx_badges_name_level`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_badges_community_name_level` ON `badges` (`community_id`, `name`, `level`);
@@ -0,0 +1 @@
+ALTER TABLE `follower_roles` ADD IF NOT EXISTS `granted_by_claimed_quest_id` VARCHAR(256) NULL;
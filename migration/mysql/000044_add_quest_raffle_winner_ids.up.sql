@@ -0,0 +1,2 @@
+ALTER TABLE `quest_raffles` ADD IF NOT EXISTS `winner_claimed_quest_ids` longblob;
+ALTER TABLE `quest_raffles` ADD IF NOT EXISTS `winner_user_ids` longblob;
@@ -0,0 +1,3 @@
+ALTER TABLE `badges` ADD IF NOT EXISTS `community_key` VARCHAR(256) AS (COALESCE(`community_id`, '')) STORED;
+ALTER TABLE `badges` DROP INDEX IF EXISTS `idx_badges_community_name_level`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_badges_community_key_name_level` ON `badges` (`community_key`, `name`, `level`);
@@ -16,8 +16,9 @@ const userAgent = "DiscordBot (https://xquest.xyz, 1.0)"
 const iso8601 = "2006-01-02T15:04:05.000000+00:00"
 
 const (
-	giveRoleResource       = "give_role"
-	getGuildInviteResource = "get_guild_invite"
+	giveRoleResource          = "give_role"
+	getGuildInviteResource    = "get_guild_invite"
+	getChannelMessageResource = "get_channel_message"
 )
 
 type Endpoint struct {
@@ -363,6 +364,92 @@ func (e *Endpoint) GiveRole(ctx context.Context, guildID, userID, roleID string)
 	return nil
 }
 
+func (e *Endpoint) GetChannel(ctx context.Context, channelID string) (Channel, error) {
+	resp, err := e.apiGenerator.New("/channels/%s", channelID).
+		Header("User-Agent", userAgent).
+		GET(ctx, api.OAuth2("Bot", e.BotToken))
+	if err != nil {
+		return Channel{}, err
+	}
+
+	body, ok := resp.Body.(api.JSON)
+	if !ok {
+		return Channel{}, errors.New("invalid response")
+	}
+
+	id, err := body.GetString("id")
+	if err != nil {
+		return Channel{}, err
+	}
+
+	// Direct message channels don't belong to any guild.
+	guildID, _ := body.GetString("guild_id")
+	name, _ := body.GetString("name")
+
+	return Channel{ID: id, GuildID: guildID, Name: name}, nil
+}
+
+func (e *Endpoint) GetChannelMessages(
+	ctx context.Context, channelID, afterID string, limit int,
+) ([]Message, error) {
+	if err := e.checkLimitingResource(getChannelMessageResource, channelID); err != nil {
+		return nil, err
+	}
+
+	resp, err := e.apiGenerator.New("/channels/%s/messages", channelID).
+		Header("User-Agent", userAgent).
+		Query(api.Parameter{
+			"after": afterID,
+			"limit": strconv.Itoa(limit),
+		}).
+		GET(ctx, api.OAuth2("Bot", e.BotToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.checkTooManyRequest(resp, getChannelMessageResource, channelID); err != nil {
+		return nil, err
+	}
+
+	array, ok := resp.Body.(api.Array)
+	if !ok {
+		return nil, errors.New("invalid response")
+	}
+
+	messages := []Message{}
+	for _, obj := range array {
+		id, err := obj.GetString("id")
+		if err != nil {
+			return nil, err
+		}
+
+		authorID, err := obj.GetString("author.id")
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := obj.GetString("content")
+		if err != nil {
+			return nil, err
+		}
+
+		timestamp, err := obj.GetTime("timestamp", iso8601)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, Message{
+			ID:        id,
+			ChannelID: channelID,
+			Author:    User{ID: authorID},
+			Content:   content,
+			Timestamp: timestamp,
+		})
+	}
+
+	return messages, nil
+}
+
 func (e *Endpoint) checkLimitingResource(resource, identifier string) error {
 	if limit, ok := e.rateLimitResource.Load(resource); ok {
 		if resetAt, ok := limit.Load(identifier); ok {
@@ -48,3 +48,40 @@ func Test_Endpoint_GiveRole_TooManyRequest(t *testing.T) {
 	err = endpoint.checkLimitingResource(giveRoleResource, "guild-1")
 	require.NoError(t, err)
 }
+
+func Test_Endpoint_GetChannelMessages(t *testing.T) {
+	endpoint := New(config.DiscordConfigs{})
+	endpoint.apiGenerator = &api.MockAPIGenerator{
+		MockClient: api.MockAPIClient{
+			GETFunc: func(ctx context.Context, opts ...api.Opt) (*api.Response, error) {
+				return &api.Response{
+					Code: http.StatusOK,
+					Body: api.Array{
+						{
+							"id":        "1128574237510029383",
+							"author":    map[string]any{"id": "user-1"},
+							"content":   "gm everyone",
+							"timestamp": "2023-07-11T17:27:07.299000+00:00",
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	messages, err := endpoint.GetChannelMessages(context.Background(), "channel-1", "0", 100)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, "1128574237510029383", messages[0].ID)
+	require.Equal(t, "channel-1", messages[0].ChannelID)
+	require.Equal(t, "user-1", messages[0].Author.ID)
+	require.Equal(t, "gm everyone", messages[0].Content)
+	require.Equal(t, int64(1689096427), messages[0].Timestamp.Unix())
+}
+
+func Test_SnowflakeFromTime(t *testing.T) {
+	// Snowflake of the first message of discord epoch is zero.
+	require.Equal(t, "0", SnowflakeFromTime(time.UnixMilli(discordEpoch)))
+	require.Equal(t, "175928847298985984",
+		SnowflakeFromTime(time.UnixMilli(discordEpoch+41944705796)))
+}
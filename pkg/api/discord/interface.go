@@ -11,4 +11,6 @@ type IEndpoint interface {
 	GetGuild(ctx context.Context, guildID string) (Guild, error)
 	GetRoles(ctx context.Context, guildID string) ([]Role, error)
 	GiveRole(ctx context.Context, guildID, userID, roleID string) error
+	GetChannel(ctx context.Context, channelID string) (Channel, error)
+	GetChannelMessages(ctx context.Context, channelID, afterID string, limit int) ([]Message, error)
 }
@@ -9,6 +9,12 @@ type Guild struct {
 	OwnerID string
 }
 
+type Channel struct {
+	ID      string
+	GuildID string
+	Name    string
+}
+
 type Role struct {
 	ID          string
 	Name        string
@@ -35,3 +41,11 @@ type InviteCode struct {
 	CreatedAt time.Time
 	Inviter   User
 }
+
+type Message struct {
+	ID        string
+	ChannelID string
+	Author    User
+	Content   string
+	Timestamp time.Time
+}
@@ -31,3 +31,17 @@ func IsRateLimit(err error) (time.Time, bool) {
 func wrapRateLimit(resetAt int64) error {
 	return fmt.Errorf("%w:%d", ErrRateLimit, resetAt)
 }
+
+// discordEpoch is the first second of 2015, in milliseconds since unix epoch.
+const discordEpoch = 1420070400000
+
+// SnowflakeFromTime returns the smallest snowflake ID generated at the given
+// time. It is used to paginate discord resources by time.
+func SnowflakeFromTime(t time.Time) string {
+	ms := t.UnixMilli() - discordEpoch
+	if ms < 0 {
+		ms = 0
+	}
+
+	return strconv.FormatInt(ms<<22, 10)
+}
@@ -111,3 +111,38 @@ func (e *Endpoint) CheckAndGetReply(ctx context.Context, author, tweetID, replyT
 
 	return tweet, nil
 }
+
+func (e *Endpoint) CheckAndGetQuote(ctx context.Context, author, tweetID, quoteTo string) (Tweet, error) {
+	resp, err := e.apiGenerator.New("/check_and_get_quote").
+		Query(api.Parameter{
+			"author":      author,
+			"tweet_id":    tweetID,
+			"quote_to_id": quoteTo,
+		}).
+		GET(ctx)
+
+	if err != nil {
+		return Tweet{}, err
+	}
+
+	if resp.Code != 200 {
+		xcontext.Logger(ctx).Errorf("Invalid status code: %v", resp.Body)
+		return Tweet{}, fmt.Errorf("invalid status code %d", resp.Code)
+	}
+
+	body, ok := resp.Body.(api.JSON)
+	if !ok {
+		return Tweet{}, errors.New("invalid body format")
+	}
+
+	tweet := Tweet{}
+	if err := mapstructure.Decode(body, &tweet); err != nil {
+		return Tweet{}, nil
+	}
+
+	if tweet.QuotedTweetID != quoteTo {
+		return Tweet{}, errors.New("the tweet doesn't quote the expected tweet")
+	}
+
+	return tweet, nil
+}
@@ -6,4 +6,5 @@ type IEndpoint interface {
 	GetUser(ctx context.Context, userScreenName string) (User, error)
 	GetTweet(ctx context.Context, author, tweetID string) (Tweet, error)
 	CheckAndGetReply(ctx context.Context, author, tweetID, replyTo string) (Tweet, error)
+	CheckAndGetQuote(ctx context.Context, author, tweetID, quoteTo string) (Tweet, error)
 }
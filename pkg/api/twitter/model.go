@@ -4,10 +4,16 @@ type User struct {
 	Name     string `mapstructure:"name"`
 	Handle   string `mapstructure:"handle"`
 	PhotoURL string `mapstructure:"photo_url"`
+
+	FollowersCount int `mapstructure:"followers_count"`
+	// CreatedAt is the unix timestamp (in seconds) when the account was created.
+	CreatedAt int64 `mapstructure:"created_at"`
 }
 
 type Tweet struct {
 	ID     string `mapstructure:"id"`
 	Author string `mapstructure:"author"`
 	Text   string `mapstructure:"text"`
+
+	QuotedTweetID string `mapstructure:"quoted_tweet_id"`
 }
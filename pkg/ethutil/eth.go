@@ -5,8 +5,11 @@ import (
 	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"golang.org/x/crypto/sha3"
@@ -38,3 +41,27 @@ func GeneratePublicKey(secret, nonce []byte) (common.Address, error) {
 
 	return crypto.PubkeyToAddress(walletPrivateKey.PublicKey), nil
 }
+
+// RecoverTextSigner returns the address which signed the text message with
+// personal_sign (EIP-191).
+func RecoverTextSigner(message []byte, hexSignature string) (common.Address, error) {
+	signature, err := hexutil.Decode(hexSignature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if len(signature) != ethcrypto.SignatureLength {
+		return common.Address{}, errors.New("invalid signature length")
+	}
+
+	if signature[ethcrypto.RecoveryIDOffset] == 27 || signature[ethcrypto.RecoveryIDOffset] == 28 {
+		signature[ethcrypto.RecoveryIDOffset] -= 27 // Transform yellow paper V from 27/28 to 0/1
+	}
+
+	recovered, err := ethcrypto.SigToPub(accounts.TextHash(message), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return ethcrypto.PubkeyToAddress(*recovered), nil
+}
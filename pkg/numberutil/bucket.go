@@ -15,3 +15,9 @@ func BucketFrom(id int64) int64 {
 
 	return time.Now().UnixMilli() / BucketDuration
 }
+
+// SnowflakeFromTime returns the smallest snowflake id generated at the given
+// time.
+func SnowflakeFromTime(t time.Time) int64 {
+	return (t.UnixMilli() - snowflake.Epoch) << 22
+}
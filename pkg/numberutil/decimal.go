@@ -0,0 +1,68 @@
+package numberutil
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseDecimal converts a non-negative decimal string (e.g. "1.5") to an integer
+// in the smallest unit with the given number of decimals (e.g. 1500000 with 6
+// decimals). The conversion is exact, a value which has more fractional digits
+// than decimals is rejected instead of being rounded.
+func ParseDecimal(s string, decimals int) (*big.Int, error) {
+	integer, fraction, _ := strings.Cut(s, ".")
+	if integer == "" && fraction == "" {
+		return nil, errors.New("empty decimal")
+	}
+
+	if !isDigits(integer) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid decimal %s", s)
+	}
+
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("decimal %s has more than %d fractional digits", s, decimals)
+	}
+
+	digits := integer + fraction + strings.Repeat("0", decimals-len(fraction))
+	result, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %s", s)
+	}
+
+	return result, nil
+}
+
+// FormatDecimal is the reverse of ParseDecimal, trailing zeros of the
+// fractional part are removed.
+func FormatDecimal(value *big.Int, decimals int) string {
+	digits := new(big.Int).Abs(value).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	integer := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	result := integer
+	if fraction != "" {
+		result += "." + fraction
+	}
+
+	if value.Sign() < 0 {
+		result = "-" + result
+	}
+
+	return result
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -25,6 +25,10 @@ type MiddlewareFunc func(ctx context.Context) (context.Context, error)
 type CloserFunc func(ctx context.Context)
 type WebsocketHandlerFunc[Request any] func(ctx context.Context, req *Request) error
 
+// RawHandlerFunc writes the response by itself through the HTTP writer of
+// context, it is useful for streaming a large response.
+type RawHandlerFunc[Request any] func(ctx context.Context, req *Request) error
+
 type Router struct {
 	mux *http.ServeMux
 	ctx context.Context
@@ -56,6 +60,10 @@ func Websocket[Request any](router *Router, pattern string, handler WebsocketHan
 	routeWS(router, pattern, handler)
 }
 
+func GETRaw[Request any](router *Router, pattern string, handler RawHandlerFunc[Request]) {
+	routeRaw(router, http.MethodGet, pattern, handler)
+}
+
 func route[Request, Response any](router *Router, method, pattern string, handler HandlerFunc[Request, Response]) {
 	befores := make([]MiddlewareFunc, len(router.befores))
 	afters := make([]MiddlewareFunc, len(router.afters))
@@ -82,6 +90,33 @@ func route[Request, Response any](router *Router, method, pattern string, handle
 	})
 }
 
+func routeRaw[Request any](router *Router, method, pattern string, handler RawHandlerFunc[Request]) {
+	befores := make([]MiddlewareFunc, len(router.befores))
+	afters := make([]MiddlewareFunc, len(router.afters))
+	closers := make([]CloserFunc, len(router.closers))
+
+	copy(befores, router.befores)
+	copy(afters, router.afters)
+	copy(closers, router.closers)
+
+	router.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		ctx := router.ctx
+		ctx = xcontext.WithHTTPRequest(ctx, r)
+		ctx = xcontext.WithHTTPWriter(ctx, w)
+
+		var req Request
+		err := parseRequest(ctx, pattern, method, &req)
+		if err != nil {
+			ctx = xcontext.WithError(ctx, err)
+		}
+
+		runMiddleware(ctx, befores, afters, closers, func(ctx context.Context) (any, error) {
+			// The response was written by handler.
+			return nil, handler(ctx, &req)
+		})
+	})
+}
+
 func routeWS[Request any](router *Router, pattern string, handler WebsocketHandlerFunc[Request]) {
 	befores := make([]MiddlewareFunc, len(router.befores))
 	afters := make([]MiddlewareFunc, len(router.afters))
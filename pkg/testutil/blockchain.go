@@ -0,0 +1,76 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/questx-lab/backend/internal/domain/blockchain/types"
+)
+
+type MockBlockchainCaller struct {
+	MintNFTFunc            func(ctx context.Context, communityID, chain string, nftID int64, amount int, ipfs string) error
+	ERC20TokenInfoFunc     func(ctx context.Context, chain, address string) (types.TokenInfo, error)
+	ERC20BalanceOfFunc     func(ctx context.Context, chain, tokenAddress, accountAddress string) (*big.Int, error)
+	ERC1155BalanceOfFunc   func(ctx context.Context, chain, address string, tokenID int64) (*big.Int, error)
+	TransactionReceiptFunc func(ctx context.Context, chain, txHash string) (types.TransactionReceipt, error)
+	DeployNFTFunc          func(ctx context.Context, chain string) (string, error)
+}
+
+func (c *MockBlockchainCaller) MintNFT(
+	ctx context.Context, communityID, chain string, nftID int64, amount int, ipfs string,
+) error {
+	if c.MintNFTFunc != nil {
+		return c.MintNFTFunc(ctx, communityID, chain, nftID, amount, ipfs)
+	}
+
+	return errors.New("not implemented")
+}
+
+func (c *MockBlockchainCaller) ERC20TokenInfo(ctx context.Context, chain, address string) (types.TokenInfo, error) {
+	if c.ERC20TokenInfoFunc != nil {
+		return c.ERC20TokenInfoFunc(ctx, chain, address)
+	}
+
+	return types.TokenInfo{}, errors.New("not implemented")
+}
+
+func (c *MockBlockchainCaller) ERC20BalanceOf(
+	ctx context.Context, chain, tokenAddress, accountAddress string,
+) (*big.Int, error) {
+	if c.ERC20BalanceOfFunc != nil {
+		return c.ERC20BalanceOfFunc(ctx, chain, tokenAddress, accountAddress)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+func (c *MockBlockchainCaller) ERC1155BalanceOf(
+	ctx context.Context, chain, address string, tokenID int64,
+) (*big.Int, error) {
+	if c.ERC1155BalanceOfFunc != nil {
+		return c.ERC1155BalanceOfFunc(ctx, chain, address, tokenID)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+func (c *MockBlockchainCaller) TransactionReceipt(
+	ctx context.Context, chain, txHash string,
+) (types.TransactionReceipt, error) {
+	if c.TransactionReceiptFunc != nil {
+		return c.TransactionReceiptFunc(ctx, chain, txHash)
+	}
+
+	return types.TransactionReceipt{}, errors.New("not implemented")
+}
+
+func (c *MockBlockchainCaller) DeployNFT(ctx context.Context, chain string) (string, error) {
+	if c.DeployNFTFunc != nil {
+		return c.DeployNFTFunc(ctx, chain)
+	}
+
+	return "", errors.New("not implemented")
+}
+
+func (c *MockBlockchainCaller) Close() {}
@@ -0,0 +1,133 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+
+	"github.com/questx-lab/backend/internal/entity"
+	"github.com/questx-lab/backend/internal/repository"
+)
+
+type MockChatMessageRepository struct {
+	CreateFunc               func(ctx context.Context, data *entity.ChatMessage) error
+	GetFunc                  func(ctx context.Context, id, channelID int64) (*entity.ChatMessage, error)
+	UpdateByIDFunc           func(ctx context.Context, id, channelID int64, content string, attachments []entity.Attachment) error
+	DeleteFunc               func(ctx context.Context, channelID, bucket, id int64) error
+	GetListByLastMessageFunc func(ctx context.Context, filter repository.LastMessageFilter) ([]entity.ChatMessage, error)
+}
+
+func (r *MockChatMessageRepository) Create(ctx context.Context, data *entity.ChatMessage) error {
+	if r.CreateFunc != nil {
+		return r.CreateFunc(ctx, data)
+	}
+
+	return errors.New("not implemented")
+}
+
+func (r *MockChatMessageRepository) Get(ctx context.Context, id, channelID int64) (*entity.ChatMessage, error) {
+	if r.GetFunc != nil {
+		return r.GetFunc(ctx, id, channelID)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+func (r *MockChatMessageRepository) UpdateByID(
+	ctx context.Context, id, channelID int64, content string, attachments []entity.Attachment,
+) error {
+	if r.UpdateByIDFunc != nil {
+		return r.UpdateByIDFunc(ctx, id, channelID, content, attachments)
+	}
+
+	return errors.New("not implemented")
+}
+
+func (r *MockChatMessageRepository) Delete(ctx context.Context, channelID, bucket, id int64) error {
+	if r.DeleteFunc != nil {
+		return r.DeleteFunc(ctx, channelID, bucket, id)
+	}
+
+	return errors.New("not implemented")
+}
+
+func (r *MockChatMessageRepository) GetListByLastMessage(
+	ctx context.Context, filter repository.LastMessageFilter,
+) ([]entity.ChatMessage, error) {
+	if r.GetListByLastMessageFunc != nil {
+		return r.GetListByLastMessageFunc(ctx, filter)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+type MockChatReactionRepository struct {
+	AddFunc               func(ctx context.Context, messageID int64, emoji entity.Emoji, userID string) error
+	RemoveFunc            func(ctx context.Context, messageID int64, emoji entity.Emoji, userID string) error
+	RemoveByMessageIDFunc func(ctx context.Context, messageID int64) error
+	CheckUserReactionFunc func(ctx context.Context, userID string, messageID int64, emoji entity.Emoji) (bool, error)
+	GetFunc               func(ctx context.Context, messageID int64, emoji entity.Emoji) (*entity.ChatReaction, error)
+	GetByMessageIDFunc    func(ctx context.Context, messageID int64) ([]entity.ChatReaction, error)
+	GetByMessageIDsFunc   func(ctx context.Context, messageIDs []int64) ([]entity.ChatReaction, error)
+}
+
+func (r *MockChatReactionRepository) Add(ctx context.Context, messageID int64, emoji entity.Emoji, userID string) error {
+	if r.AddFunc != nil {
+		return r.AddFunc(ctx, messageID, emoji, userID)
+	}
+
+	return errors.New("not implemented")
+}
+
+func (r *MockChatReactionRepository) Remove(ctx context.Context, messageID int64, emoji entity.Emoji, userID string) error {
+	if r.RemoveFunc != nil {
+		return r.RemoveFunc(ctx, messageID, emoji, userID)
+	}
+
+	return errors.New("not implemented")
+}
+
+func (r *MockChatReactionRepository) RemoveByMessageID(ctx context.Context, messageID int64) error {
+	if r.RemoveByMessageIDFunc != nil {
+		return r.RemoveByMessageIDFunc(ctx, messageID)
+	}
+
+	return errors.New("not implemented")
+}
+
+func (r *MockChatReactionRepository) CheckUserReaction(
+	ctx context.Context, userID string, messageID int64, emoji entity.Emoji,
+) (bool, error) {
+	if r.CheckUserReactionFunc != nil {
+		return r.CheckUserReactionFunc(ctx, userID, messageID, emoji)
+	}
+
+	return false, errors.New("not implemented")
+}
+
+func (r *MockChatReactionRepository) Get(
+	ctx context.Context, messageID int64, emoji entity.Emoji,
+) (*entity.ChatReaction, error) {
+	if r.GetFunc != nil {
+		return r.GetFunc(ctx, messageID, emoji)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+func (r *MockChatReactionRepository) GetByMessageID(ctx context.Context, messageID int64) ([]entity.ChatReaction, error) {
+	if r.GetByMessageIDFunc != nil {
+		return r.GetByMessageIDFunc(ctx, messageID)
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+func (r *MockChatReactionRepository) GetByMessageIDs(
+	ctx context.Context, messageIDs []int64,
+) ([]entity.ChatReaction, error) {
+	if r.GetByMessageIDsFunc != nil {
+		return r.GetByMessageIDsFunc(ctx, messageIDs)
+	}
+
+	return nil, errors.New("not implemented")
+}
@@ -12,6 +12,7 @@ type MockTwitterEndpoint struct {
 	GetUserFunc          func(context.Context, string) (twitter.User, error)
 	GetTweetFunc         func(context.Context, string, string) (twitter.Tweet, error)
 	CheckAndGetReplyFunc func(ctx context.Context, author, tweetID, replyTo string) (twitter.Tweet, error)
+	CheckAndGetQuoteFunc func(ctx context.Context, author, tweetID, quoteTo string) (twitter.Tweet, error)
 }
 
 func (e *MockTwitterEndpoint) GetUser(ctx context.Context, id string) (twitter.User, error) {
@@ -38,6 +39,14 @@ func (e *MockTwitterEndpoint) CheckAndGetReply(ctx context.Context, author, twee
 	return twitter.Tweet{}, errors.New("not implemented")
 }
 
+func (e *MockTwitterEndpoint) CheckAndGetQuote(ctx context.Context, author, tweetID, quoteTo string) (twitter.Tweet, error) {
+	if e.CheckAndGetQuoteFunc != nil {
+		return e.CheckAndGetQuoteFunc(ctx, author, tweetID, quoteTo)
+	}
+
+	return twitter.Tweet{}, errors.New("not implemented")
+}
+
 type MockDiscordEndpoint struct {
 	GetMeFunc       func(ctx context.Context, token string) (discord.User, error)
 	HasAddedBotFunc func(ctx context.Context, guildID string) (bool, error)
@@ -47,6 +56,9 @@ type MockDiscordEndpoint struct {
 	GetGuildFunc    func(ctx context.Context, guildID string) (discord.Guild, error)
 	GetRolesFunc    func(ctx context.Context, guildID string) ([]discord.Role, error)
 	GiveRoleFunc    func(ctx context.Context, guildID, userID, roleID string) error
+
+	GetChannelFunc         func(ctx context.Context, channelID string) (discord.Channel, error)
+	GetChannelMessagesFunc func(ctx context.Context, channelID, afterID string, limit int) ([]discord.Message, error)
 }
 
 func (e *MockDiscordEndpoint) GetMe(ctx context.Context, token string) (discord.User, error) {
@@ -112,3 +124,21 @@ func (e *MockDiscordEndpoint) GiveRole(ctx context.Context, guildID, userID, rol
 
 	return errors.New("not implemented")
 }
+
+func (e *MockDiscordEndpoint) GetChannel(ctx context.Context, channelID string) (discord.Channel, error) {
+	if e.GetChannelFunc != nil {
+		return e.GetChannelFunc(ctx, channelID)
+	}
+
+	return discord.Channel{}, errors.New("not implemented")
+}
+
+func (e *MockDiscordEndpoint) GetChannelMessages(
+	ctx context.Context, channelID, afterID string, limit int,
+) ([]discord.Message, error) {
+	if e.GetChannelMessagesFunc != nil {
+		return e.GetChannelMessagesFunc(ctx, channelID, afterID, limit)
+	}
+
+	return nil, errors.New("not implemented")
+}
@@ -8,10 +8,14 @@ import (
 	"github.com/alicebob/miniredis/v2"
 	"github.com/gorilla/sessions"
 	"github.com/questx-lab/backend/config"
+	"github.com/questx-lab/backend/internal/client"
 	"github.com/questx-lab/backend/internal/common"
+	"github.com/questx-lab/backend/internal/domain/badge"
 	"github.com/questx-lab/backend/internal/domain/questclaim"
 	"github.com/questx-lab/backend/internal/repository"
 	"github.com/questx-lab/backend/migration"
+	"github.com/questx-lab/backend/pkg/api/discord"
+	"github.com/questx-lab/backend/pkg/api/twitter"
 	"github.com/questx-lab/backend/pkg/logger"
 	"github.com/questx-lab/backend/pkg/token"
 	"github.com/questx-lab/backend/pkg/xcontext"
@@ -24,6 +28,36 @@ import (
 type redisClientKey struct{}
 
 func NewQuestFactory(ctx context.Context) questclaim.Factory {
+	return NewQuestFactoryWithMocks(ctx, QuestFactoryMocks{})
+}
+
+// QuestFactoryMocks replaces external dependencies of the quest factory. Nil
+// fields are replaced with the default mocks.
+type QuestFactoryMocks struct {
+	TwitterEndpoint  twitter.IEndpoint
+	DiscordEndpoint  discord.IEndpoint
+	BlockchainCaller client.BlockchainCaller
+	ChatMessageRepo  repository.ChatMessageRepository
+	ChatReactionRepo repository.ChatReactionRepository
+}
+
+func NewQuestFactoryWithMocks(ctx context.Context, mocks QuestFactoryMocks) questclaim.Factory {
+	badgeRepo := repository.NewBadgeRepository()
+	badgeDetailRepo := repository.NewBadgeDetailRepository()
+	followerRepo := repository.NewFollowerRepository()
+
+	if mocks.TwitterEndpoint == nil {
+		mocks.TwitterEndpoint = &MockTwitterEndpoint{}
+	}
+
+	if mocks.DiscordEndpoint == nil {
+		mocks.DiscordEndpoint = &MockDiscordEndpoint{}
+	}
+
+	if mocks.BlockchainCaller == nil {
+		mocks.BlockchainCaller = &MockBlockchainCaller{}
+	}
+
 	return questclaim.NewFactory(
 		repository.NewClaimedQuestRepository(),
 		repository.NewQuestRepository(&MockSearchCaller{}),
@@ -35,8 +69,22 @@ func NewQuestFactory(ctx context.Context) questclaim.Factory {
 		repository.NewBlockChainRepository(),
 		repository.NewLotteryRepository(),
 		repository.NewNftRepository(),
-		&MockTwitterEndpoint{}, &MockDiscordEndpoint{},
-		nil,
+		repository.NewChatChannelRepository(),
+		mocks.ChatMessageRepo,
+		mocks.ChatReactionRepo,
+		badgeRepo,
+		badgeDetailRepo,
+		repository.NewRoleRepository(),
+		repository.NewFollowerRoleRepository(),
+		badge.NewManager(
+			badgeRepo,
+			badgeDetailRepo,
+			badge.NewSharpScoutBadgeScanner(badgeRepo, followerRepo),
+			badge.NewRainBowBadgeScanner(badgeRepo, followerRepo),
+			badge.NewQuestWarriorBadgeScanner(badgeRepo, followerRepo),
+		),
+		mocks.TwitterEndpoint, mocks.DiscordEndpoint,
+		nil, mocks.BlockchainCaller,
 	)
 }
 
@@ -69,10 +117,16 @@ func MockContext(t *testing.T) context.Context {
 				Name:       "refresh_token",
 				Expiration: time.Minute,
 			},
+			Twitter: config.OAuth2Config{Name: "twitter"},
+			Discord: config.OAuth2Config{Name: "discord"},
 		},
 		Session: config.SessionConfigs{
 			Secret: "session-secret",
 		},
+		File: config.FileConfigs{
+			MaxMemory: 2 << 20,
+			MaxSize:   2 << 20,
+		},
 		Quest: config.QuestConfigs{
 			QuizMaxQuestions:                  10,
 			QuizMaxOptions:                    10,
@@ -80,6 +134,7 @@ func MockContext(t *testing.T) context.Context {
 			InviteCommunityRewardChain:        "avaxc-testnet",
 			InviteCommunityRewardTokenAddress: "USDT",
 			InviteCommunityRewardAmount:       10,
+			ReviewLeaseTimeout:                10 * time.Minute,
 		},
 	}
 
@@ -13,6 +13,7 @@ type Client interface {
 	Exist(ctx context.Context, key string) (bool, error)
 	Del(ctx context.Context, key ...string) error
 	Keys(ctx context.Context, pattern string) ([]string, error)
+	Expire(ctx context.Context, key string, ttl time.Duration) error
 
 	// Sorted list
 	ZAdd(ctx context.Context, key string, z redis.Z) error
@@ -29,6 +30,7 @@ type Client interface {
 
 	// Single object
 	Set(ctx context.Context, key, value string) error
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
 	SetObj(ctx context.Context, key string, obj any, ttl time.Duration) error
 	MSet(ctx context.Context, kv map[string]any) error
 	Get(ctx context.Context, key string) (string, error)
@@ -82,6 +84,10 @@ func (c *client) Exist(ctx context.Context, key string) (bool, error) {
 	return true, nil
 }
 
+func (c *client) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.redisClient.Expire(ctx, key, ttl).Err()
+}
+
 func (c *client) Del(ctx context.Context, key ...string) error {
 	err := c.redisClient.Del(ctx, key...).Err()
 	if err == nil || err == redis.Nil {
@@ -157,6 +163,10 @@ func (c *client) Set(ctx context.Context, key, value string) error {
 	return c.redisClient.Set(ctx, key, value, -1).Err()
 }
 
+func (c *client) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	return c.redisClient.SetNX(ctx, key, value, ttl).Result()
+}
+
 func (c *client) SetObj(ctx context.Context, key string, obj any, ttl time.Duration) error {
 	b, err := json.Marshal(obj)
 	if err != nil {